@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/cmwaters/skychart/types"
 )
@@ -15,6 +17,7 @@ import (
 // parsing the corresponding response
 type Client struct {
 	registryUrl string
+	client      *http.Client
 }
 
 func New(registryUrl string) (*Client, error) {
@@ -22,7 +25,13 @@ func New(registryUrl string) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Client{registryUrl: registryUrl}, nil
+	return &Client{registryUrl: registryUrl, client: http.DefaultClient}, nil
+}
+
+// SetHTTPClient sets the client the requests are made with. It defaults to
+// http.DefaultClient.
+func (c *Client) SetHTTPClient(client *http.Client) {
+	c.client = client
 }
 
 func (c Client) Chains() ([]string, error) {
@@ -52,6 +61,32 @@ func (c Client) Assets() ([]string, error) {
 	return assets, nil
 }
 
+func (c Client) Providers() ([]string, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/providers", c.registryUrl))
+	if err != nil {
+		return nil, err
+	}
+	var providers []string
+	err = json.Unmarshal(bz, &providers)
+	if err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+func (c Client) Images() ([]types.Image, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/images", c.registryUrl))
+	if err != nil {
+		return nil, err
+	}
+	var images []types.Image
+	err = json.Unmarshal(bz, &images)
+	if err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
 func (c Client) Chain(chain string) (types.Chain, error) {
 	bz, err := c.get(fmt.Sprintf("%s/v1/chain/%s", c.registryUrl, chain))
 	if err != nil {
@@ -78,6 +113,19 @@ func (c Client) Asset(name string) (types.AssetElement, error) {
 	return resp, nil
 }
 
+func (c Client) AssetLineage(name string) (types.AssetLineage, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/asset/%s/lineage", c.registryUrl, name))
+	if err != nil {
+		return types.AssetLineage{}, err
+	}
+	var resp types.AssetLineage
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.AssetLineage{}, err
+	}
+	return resp, nil
+}
+
 func (c Client) RPC(chain string) ([]types.GrpcElement, error) {
 	bz, err := c.get(fmt.Sprintf("%s/v1/chain/%s/endpoints/rpc", c.registryUrl, chain))
 	if err != nil {
@@ -143,11 +191,190 @@ func (c Client) Seeds(chain string) ([]types.PersistentPeerElement, error) {
 	return resp, nil
 }
 
+func (c Client) Keplr(chain string) (types.ChainInfo, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/chain/%s/keplr", c.registryUrl, chain))
+	if err != nil {
+		return types.ChainInfo{}, err
+	}
+	var resp types.ChainInfo
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.ChainInfo{}, err
+	}
+	return resp, nil
+}
+
+func (c Client) Leap(chain string) (types.ChainInfo, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/chain/%s/wallet/leap", c.registryUrl, chain))
+	if err != nil {
+		return types.ChainInfo{}, err
+	}
+	var resp types.ChainInfo
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.ChainInfo{}, err
+	}
+	return resp, nil
+}
+
+func (c Client) Cosmostation(chain string) (types.CosmostationChain, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/chain/%s/wallet/cosmostation", c.registryUrl, chain))
+	if err != nil {
+		return types.CosmostationChain{}, err
+	}
+	var resp types.CosmostationChain
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.CosmostationChain{}, err
+	}
+	return resp, nil
+}
+
+func (c Client) HermesConfig(chains ...string) (string, error) {
+	query := url.Values{"chains": []string{strings.Join(chains, ",")}}
+	bz, err := c.get(fmt.Sprintf("%s/v1/relayer/hermes/config?%s", c.registryUrl, query.Encode()))
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+func (c Client) RlyChain(chain string) (types.RlyChain, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/relayer/rly/chains/%s", c.registryUrl, chain))
+	if err != nil {
+		return types.RlyChain{}, err
+	}
+	var resp types.RlyChain
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.RlyChain{}, err
+	}
+	return resp, nil
+}
+
+func (c Client) History(chain string) (types.ChainHistory, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/chain/%s/history", c.registryUrl, chain))
+	if err != nil {
+		return types.ChainHistory{}, err
+	}
+	var resp types.ChainHistory
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.ChainHistory{}, err
+	}
+	return resp, nil
+}
+
+func (c Client) Changes(since time.Time) (types.Changes, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/changes?since=%d", c.registryUrl, since.Unix()))
+	if err != nil {
+		return types.Changes{}, err
+	}
+	var resp types.Changes
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.Changes{}, err
+	}
+	return resp, nil
+}
+
+func (c Client) Stats() (types.Stats, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/stats", c.registryUrl))
+	if err != nil {
+		return types.Stats{}, err
+	}
+	var resp types.Stats
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.Stats{}, err
+	}
+	return resp, nil
+}
+
+func (c Client) RegistryStats() (types.RegistryStats, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/stats/registry", c.registryUrl))
+	if err != nil {
+		return types.RegistryStats{}, err
+	}
+	var resp types.RegistryStats
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.RegistryStats{}, err
+	}
+	return resp, nil
+}
+
+func (c Client) ProviderStats() ([]types.ProviderStats, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/stats/providers", c.registryUrl))
+	if err != nil {
+		return nil, err
+	}
+	var resp []types.ProviderStats
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c Client) GasPrices() (map[string][]types.FeeTokenElement, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/gas-prices", c.registryUrl))
+	if err != nil {
+		return nil, err
+	}
+	var resp map[string][]types.FeeTokenElement
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c Client) GasPrice(chain string) ([]types.FeeTokenElement, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/chain/%s/gas-price", c.registryUrl, chain))
+	if err != nil {
+		return nil, err
+	}
+	var resp []types.FeeTokenElement
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c Client) Fees(chain string) ([]types.FeeToken, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/chain/%s/fees", c.registryUrl, chain))
+	if err != nil {
+		return nil, err
+	}
+	var resp []types.FeeToken
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c Client) Supply(asset string) (types.AssetSupply, error) {
+	bz, err := c.get(fmt.Sprintf("%s/v1/asset/%s/supply", c.registryUrl, asset))
+	if err != nil {
+		return types.AssetSupply{}, err
+	}
+	var resp types.AssetSupply
+	err = json.Unmarshal(bz, &resp)
+	if err != nil {
+		return types.AssetSupply{}, err
+	}
+	return resp, nil
+}
+
 func (c Client) get(query string) ([]byte, error) {
-	resp, err := http.Get(query)
+	resp, err := c.client.Get(query)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusNotFound {
 		return nil, errors.New("resource not found")
 	}
@@ -155,7 +382,7 @@ func (c Client) get(query string) ([]byte, error) {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/cmwaters/skychart/client"
+	"github.com/cmwaters/skychart/types"
+)
+
+const usage = `skychartctl queries a running skychart server
+
+Usage: skychartctl [flags] command [args]
+
+Commands:
+  chains                       list the names of all chains
+  chain <chain>                show a chain
+  endpoints <chain> [type]     list a chain's endpoints, where type is one of
+                               rpc (default), rest, grpc, peers or seeds
+  assets                       list the display names of all assets
+  asset <asset>                show an asset
+
+Flags:
+`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("skychartctl", flag.ContinueOnError)
+	defaultUrl := os.Getenv("SKYCHART_URL")
+	if defaultUrl == "" {
+		defaultUrl = "http://localhost:8080"
+	}
+	serverUrl := fs.String("url", defaultUrl, "url of the skychart server (env SKYCHART_URL)")
+	output := fs.String("output", "table", "output format: table or json")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("no command given")
+	}
+	if *output != "table" && *output != "json" {
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+
+	c, err := client.New(*serverUrl)
+	if err != nil {
+		return err
+	}
+	p := printer{json: *output == "json"}
+
+	cmd, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	switch cmd {
+	case "chains":
+		chains, err := c.Chains()
+		if err != nil {
+			return err
+		}
+		return p.list(chains)
+
+	case "chain":
+		if len(cmdArgs) != 1 {
+			return errors.New("usage: skychartctl chain <chain>")
+		}
+		chain, err := c.Chain(cmdArgs[0])
+		if err != nil {
+			return err
+		}
+		return p.chain(chain)
+
+	case "endpoints":
+		if len(cmdArgs) < 1 || len(cmdArgs) > 2 {
+			return errors.New("usage: skychartctl endpoints <chain> [type]")
+		}
+		endpointType := "rpc"
+		if len(cmdArgs) == 2 {
+			endpointType = cmdArgs[1]
+		}
+		return endpoints(c, p, cmdArgs[0], endpointType)
+
+	case "assets":
+		assets, err := c.Assets()
+		if err != nil {
+			return err
+		}
+		return p.list(assets)
+
+	case "asset":
+		if len(cmdArgs) != 1 {
+			return errors.New("usage: skychartctl asset <asset>")
+		}
+		asset, err := c.Asset(cmdArgs[0])
+		if err != nil {
+			return err
+		}
+		return p.asset(asset)
+
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+func endpoints(c *client.Client, p printer, chain, endpointType string) error {
+	switch endpointType {
+	case "rpc", "rest", "grpc":
+		var (
+			endpoints []types.GrpcElement
+			err       error
+		)
+		switch endpointType {
+		case "rpc":
+			endpoints, err = c.RPC(chain)
+		case "rest":
+			endpoints, err = c.REST(chain)
+		case "grpc":
+			endpoints, err = c.GRPC(chain)
+		}
+		if err != nil {
+			return err
+		}
+		return p.endpoints(endpoints)
+
+	case "peers", "seeds":
+		var (
+			peers []types.PersistentPeerElement
+			err   error
+		)
+		if endpointType == "peers" {
+			peers, err = c.Peers(chain)
+		} else {
+			peers, err = c.Seeds(chain)
+		}
+		if err != nil {
+			return err
+		}
+		return p.peers(peers)
+
+	default:
+		return fmt.Errorf("unknown endpoint type %q", endpointType)
+	}
+}
+
+// printer writes results either as tab aligned tables or as indented JSON
+type printer struct {
+	json bool
+}
+
+func (p printer) list(items []string) error {
+	if p.json {
+		return p.writeJSON(items)
+	}
+	for _, item := range items {
+		fmt.Println(item)
+	}
+	return nil
+}
+
+func (p printer) chain(chain types.Chain) error {
+	if p.json {
+		return p.writeJSON(chain)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "NAME\t%s\n", chain.ChainName)
+	fmt.Fprintf(w, "CHAIN ID\t%s\n", chain.ChainID)
+	fmt.Fprintf(w, "PRETTY NAME\t%s\n", deref(chain.PrettyName))
+	if chain.Status != nil {
+		fmt.Fprintf(w, "STATUS\t%s\n", *chain.Status)
+	}
+	if chain.NetworkType != nil {
+		fmt.Fprintf(w, "NETWORK TYPE\t%s\n", *chain.NetworkType)
+	}
+	fmt.Fprintf(w, "BECH32 PREFIX\t%s\n", chain.Bech32Prefix)
+	fmt.Fprintf(w, "DAEMON\t%s\n", deref(chain.DaemonName))
+	if chain.Apis != nil {
+		fmt.Fprintf(w, "RPC ENDPOINTS\t%d\n", len(chain.Apis.RPC))
+		fmt.Fprintf(w, "REST ENDPOINTS\t%d\n", len(chain.Apis.REST))
+		fmt.Fprintf(w, "GRPC ENDPOINTS\t%d\n", len(chain.Apis.Grpc))
+	}
+	return w.Flush()
+}
+
+func (p printer) asset(asset types.AssetElement) error {
+	if p.json {
+		return p.writeJSON(asset)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "NAME\t%s\n", deref(asset.Name))
+	fmt.Fprintf(w, "SYMBOL\t%s\n", deref(asset.Symbol))
+	fmt.Fprintf(w, "BASE\t%s\n", asset.Base)
+	fmt.Fprintf(w, "DISPLAY\t%s\n", asset.Display)
+	fmt.Fprintf(w, "COINGECKO ID\t%s\n", deref(asset.CoingeckoID))
+	return w.Flush()
+}
+
+func (p printer) endpoints(endpoints []types.GrpcElement) error {
+	if p.json {
+		return p.writeJSON(endpoints)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ADDRESS\tPROVIDER")
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(w, "%s\t%s\n", endpoint.Address, deref(endpoint.Provider))
+	}
+	return w.Flush()
+}
+
+func (p printer) peers(peers []types.PersistentPeerElement) error {
+	if p.json {
+		return p.writeJSON(peers)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tADDRESS\tPROVIDER")
+	for _, peer := range peers {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", peer.ID, peer.Address, deref(peer.Provider))
+	}
+	return w.Flush()
+}
+
+func (p printer) writeJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
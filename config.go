@@ -0,0 +1,776 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/cmwaters/skychart/server"
+)
+
+// Config contains all the settings of the skychart server. It can be loaded
+// from a YAML file with the --config flag. Unset values take their defaults.
+type Config struct {
+	// Registry is the github repo of the chain-registry, e.g. cosmos/chain-registry
+	Registry string `yaml:"registry"`
+	// Branch is the branch of the registry to serve
+	Branch string `yaml:"branch"`
+	// Pin serves the registry as of a commit SHA or tag instead of the branch.
+	// The registry is pulled once and never updated.
+	Pin string `yaml:"pin"`
+	// Archive pulls the registry from github as a single tarball of the repo
+	// instead of fetching the files of every chain separately
+	Archive bool `yaml:"archive"`
+	// GraphQL pulls the files of the registry through github's GraphQL API
+	// in a handful of queries. It requires a GitHubToken.
+	GraphQL bool `yaml:"graphql"`
+	// GitHubToken authenticates the requests to github, raising its rate
+	// limit. The GITHUB_TOKEN environment variable overrides it.
+	GitHubToken string `yaml:"github_token"`
+	// UserAgent is sent with every upstream request. Defaults to skychart.
+	UserAgent string `yaml:"user_agent"`
+	// Chains restricts the chains that are pulled and served
+	Chains ChainsConfig `yaml:"chains"`
+	// Aliases are alternative names of chains and assets, keyed by the chain
+	// name or asset display name
+	Aliases map[string][]string `yaml:"aliases"`
+	// PreviousChainIDs are chain ids that chains were known by before their
+	// current one, keyed by the chain name, e.g. cosmoshub: [cosmoshub-3]
+	PreviousChainIDs map[string][]string `yaml:"previous_chain_ids"`
+	// ListenAddr is the address the server listens on, e.g. :8080
+	ListenAddr string `yaml:"listen_addr"`
+	// UnixSocket is the path of a unix socket that is also listened on, e.g.
+	// for a local reverse proxy
+	UnixSocket string `yaml:"unix_socket"`
+	// RequestTimeout is how long a request may take before it is answered
+	// with 503. Zero disables the timeout.
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// Limits bound the connections to the server so that slow clients can't
+	// exhaust it
+	Limits LimitsConfig `yaml:"limits"`
+	// TLS serves https on ListenAddr. It is disabled when unset.
+	TLS *TLSConfig `yaml:"tls"`
+	// UpdateFrequency is a cron spec of how often to pull the registry, e.g. @daily
+	UpdateFrequency string `yaml:"update_frequency"`
+	// UpdateJitter delays each pull by a random duration of up to this value
+	UpdateJitter time.Duration `yaml:"update_jitter"`
+	// MaxStaleness is how old the registry may get, while pulls are failing,
+	// before /readyz reports the server as not ready. Zero disables the check.
+	MaxStaleness time.Duration `yaml:"max_staleness"`
+	// InitialPull makes the server listen before its initial pull, reporting
+	// itself as ready once the pull succeeds. By default the server only
+	// listens once the initial pull is done.
+	InitialPull *InitialPullConfig `yaml:"initial_pull"`
+	// APIKeys maps API keys to the scopes they are granted
+	APIKeys map[string][]string `yaml:"api_keys"`
+	// AdminBasicAuth lets the admin routes authenticate with HTTP basic auth
+	// instead of an API key. It is disabled when unset.
+	AdminBasicAuth *BasicAuthConfig `yaml:"admin_basic_auth"`
+	// Import is the path of a registry snapshot, as exported from /admin/export,
+	// to seed the server with
+	Import string `yaml:"import"`
+	// Webhooks are URLs that are sent a POST request describing the changes
+	// whenever a pull changes the registry
+	Webhooks []string `yaml:"webhooks"`
+	// SlackWebhook is a Slack incoming webhook URL that is posted a summary of
+	// the changes whenever a pull changes the registry
+	SlackWebhook string `yaml:"slack_webhook"`
+	// DiscordWebhook is the Discord equivalent of SlackWebhook
+	DiscordWebhook string `yaml:"discord_webhook"`
+	// Redis shares the registry between replicas through a redis server so
+	// that only one of them pulls from github. It is disabled when unset.
+	Redis *RedisConfig `yaml:"redis"`
+	// Primary follows another skychart server, serving the registry it
+	// pulled instead of pulling from github. It is disabled when unset.
+	Primary *PrimaryConfig `yaml:"primary"`
+	// GitLab and Gitea pull the registry from a repo hosted on a GitLab or
+	// Gitea instance instead of github. Registry is the path of the repo.
+	GitLab *ForgeConfig `yaml:"gitlab"`
+	Gitea  *ForgeConfig `yaml:"gitea"`
+	// S3 pulls the registry from a bucket of an S3 compatible object store
+	// instead of github. Registry then only identifies the registry.
+	S3 *S3Config `yaml:"s3"`
+	// Dir pulls the registry from a directory on disk, such as a checkout of
+	// a monorepo, instead of github. Registry then only identifies the
+	// registry.
+	Dir string `yaml:"dir"`
+	// GenesisDir is the directory genesis files are cached in. Defaults to a
+	// directory in the system's temporary directory.
+	GenesisDir string `yaml:"genesis_dir"`
+	// Mirror serves the complete tree of the registry, including images and
+	// schemas, under /mirror. It is disabled when unset.
+	Mirror *MirrorConfig `yaml:"mirror"`
+	// SigningKey is a PEM file of an Ed25519 private key that the body of
+	// every successful response is signed with. Responses aren't signed
+	// when it is unset.
+	SigningKey string `yaml:"signing_key"`
+	// Directory serves a subset of the cosmos.directory chain API under
+	// /directory
+	Directory bool `yaml:"directory"`
+	// Postgres is the URL of a PostgreSQL database the registry is saved to
+	// after every pull and restored from on startup
+	Postgres string `yaml:"postgres"`
+	// StoreFile is the path of a file the registry is saved to after every
+	// pull and restored from on startup, as an alternative to Postgres
+	StoreFile string `yaml:"store_file"`
+	// RateLimit limits the requests per client IP. It is disabled when unset.
+	RateLimit *RateLimitConfig `yaml:"rate_limit"`
+	// CoinGecko resolves the coingecko ids of assets to their USD price,
+	// which is included in asset responses with ?include=price. It is
+	// disabled when unset.
+	CoinGecko *CoinGeckoConfig `yaml:"coingecko"`
+	// ImageChecks checks that the logos of the registry resolve, flagging
+	// the broken ones in /v1/images. It is disabled when unset.
+	ImageChecks *ImageChecksConfig `yaml:"image_checks"`
+	// LogFile writes the logs to a rotated file instead of stderr. It is
+	// disabled when unset.
+	LogFile *LogFileConfig `yaml:"log_file"`
+}
+
+type LimitsConfig struct {
+	// ReadHeaderTimeout is how long a client may take to send the headers
+	// of a request
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	// ReadTimeout is how long a client may take to send a whole request
+	ReadTimeout time.Duration `yaml:"read_timeout"`
+	// WriteTimeout is how long a client may take to read a response,
+	// including a genesis file
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	// IdleTimeout is how long a keep-alive connection may stay idle
+	IdleTimeout time.Duration `yaml:"idle_timeout"`
+	// MaxHeaderBytes is the maximum size of the headers of a request
+	MaxHeaderBytes int `yaml:"max_header_bytes"`
+	// MaxConns is the maximum number of open connections. Zero is unlimited.
+	MaxConns int `yaml:"max_conns"`
+}
+
+type BasicAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+type InitialPullConfig struct {
+	// Timeout is how long the initial pull is retried for
+	Timeout time.Duration `yaml:"timeout"`
+	// Fallback serves the registry restored from the import or store once
+	// the timeout elapses, rather than exiting
+	Fallback bool `yaml:"fallback"`
+}
+
+type MirrorConfig struct {
+	// Dir is the directory the tree is kept in. Defaults to a directory in
+	// the system's temporary directory.
+	Dir string `yaml:"dir"`
+}
+
+type ChainsConfig struct {
+	// Allow lists the only chains to pull, by their directory in the
+	// registry. Every chain is pulled when it is empty.
+	Allow []string `yaml:"allow"`
+	// Deny lists chains that are never pulled
+	Deny []string `yaml:"deny"`
+}
+
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM encoded certificate and key. The
+	// certificate is reloaded when the file changes.
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ACME obtains and renews the certificate automatically instead
+	ACME *ACMEConfig `yaml:"acme"`
+	// AdminClientCA is a PEM file of the CAs that issue the client
+	// certificates required by the admin routes. Other routes stay open.
+	AdminClientCA string `yaml:"admin_client_ca"`
+}
+
+type ACMEConfig struct {
+	// Domains the certificate is issued for. Each must resolve to the server.
+	Domains []string `yaml:"domains"`
+	// Email is notified of problems with the certificate
+	Email string `yaml:"email"`
+	// CacheDir keeps the account key and certificate across restarts
+	CacheDir string `yaml:"cache_dir"`
+	// DirectoryURL of the ACME server. Defaults to Let's Encrypt.
+	DirectoryURL string `yaml:"directory_url"`
+	// HTTPAddr serves the http-01 challenges and redirects other requests to
+	// https. Defaults to :80.
+	HTTPAddr string `yaml:"http_addr"`
+}
+
+type ForgeConfig struct {
+	// URL of the instance, e.g. https://gitlab.example.com
+	URL string `yaml:"url"`
+	// Token is an access token, only needed for private repos
+	Token string `yaml:"token"`
+}
+
+type S3Config struct {
+	// Endpoint of the object store, e.g. https://s3.us-east-1.amazonaws.com
+	// or https://storage.googleapis.com
+	Endpoint string `yaml:"endpoint"`
+	Bucket   string `yaml:"bucket"`
+	// Prefix of the registry within the bucket, e.g. chain-registry/
+	Prefix string `yaml:"prefix"`
+	// Region requests are signed for. Defaults to us-east-1.
+	Region string `yaml:"region"`
+	// AccessKeyID and SecretAccessKey default to the AWS_ACCESS_KEY_ID and
+	// AWS_SECRET_ACCESS_KEY environment variables. Requests are unsigned
+	// when neither is set.
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+}
+
+type CoinGeckoConfig struct {
+	// URL of the API. Defaults to https://api.coingecko.com/api/v3.
+	URL string `yaml:"url"`
+	// APIKey is an optional CoinGecko API key
+	APIKey string `yaml:"api_key"`
+	// Interval is how often prices are refreshed. Defaults to 5m.
+	Interval time.Duration `yaml:"interval"`
+}
+
+type ImageChecksConfig struct {
+	// Interval is how often every logo is checked. Defaults to 24h.
+	Interval time.Duration `yaml:"interval"`
+}
+
+type LogFileConfig struct {
+	// Path of the log file, e.g. /var/log/skychart.log
+	Path string `yaml:"path"`
+	// MaxSize is the size in megabytes the file can grow to before it is
+	// rotated. Zero is unlimited.
+	MaxSize int `yaml:"max_size"`
+	// MaxAge is how long the file is written to before it is rotated, e.g.
+	// 24h. Zero never rotates the file by age.
+	MaxAge time.Duration `yaml:"max_age"`
+	// MaxBackups is how many rotated files are kept. Zero keeps them all.
+	MaxBackups int `yaml:"max_backups"`
+	// Compress gzips rotated files
+	Compress bool `yaml:"compress"`
+}
+
+type RedisConfig struct {
+	// URL of the redis server, e.g. redis://localhost:6379/0
+	URL string `yaml:"url"`
+	// Prefix is prepended to every key. Defaults to "skychart".
+	Prefix string `yaml:"prefix"`
+}
+
+type PrimaryConfig struct {
+	// URL of the primary, e.g. https://skychart.example.com
+	URL string `yaml:"url"`
+	// APIKey is sent to the primary if it requires the replication scope
+	APIKey string `yaml:"api_key"`
+}
+
+type RateLimitConfig struct {
+	// Rate is the average number of requests per second allowed
+	Rate float64 `yaml:"rate"`
+	// Burst is the maximum number of requests allowed at once. Defaults to the rate.
+	Burst int `yaml:"burst"`
+	// Allowlist contains the IPs that are never rate limited
+	Allowlist []string `yaml:"allowlist"`
+	// TrustProxy identifies clients by the last address of the
+	// X-Forwarded-For header, appended by the proxy in front of the server
+	TrustProxy bool `yaml:"trust_proxy"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Branch:          server.DefaultBranch,
+		UpdateFrequency: defaultUpdateFreq,
+		RequestTimeout:  server.DefaultRequestTimeout,
+		Limits:          LimitsConfig(server.DefaultLimits),
+	}
+}
+
+// LoadConfig reads the YAML config file at path on top of the default config
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("reading config: %w", err)
+	}
+	if err := yaml.Unmarshal(bz, &cfg); err != nil {
+		return cfg, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// envPrefix starts the names of the environment variables of the settings
+const envPrefix = "SKYCHART"
+
+// ApplyEnv sets each setting of the config from its environment variable, if
+// set. The variable is named after the setting's path in the config file in
+// upper case, with the keys joined by underscores, e.g. SKYCHART_TLS_CERT_FILE
+// for tls.cert_file. Lists are comma separated. Settings that are maps, such
+// as aliases, can't be set from the environment.
+func (cfg *Config) ApplyEnv() error {
+	_, err := applyEnv(reflect.ValueOf(cfg).Elem(), envPrefix)
+	return err
+}
+
+// applyEnv sets the fields of the struct from the environment variables named
+// after their yaml keys and the prefix. It returns true if any was set.
+func applyEnv(v reflect.Value, prefix string) (bool, error) {
+	set := false
+	for i := 0; i < v.NumField(); i++ {
+		field, value := v.Type().Field(i), v.Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		name := prefix + "_" + strings.ToUpper(key)
+
+		switch {
+		case value.Kind() == reflect.Struct:
+			nested, err := applyEnv(value, name)
+			if err != nil {
+				return false, err
+			}
+			set = set || nested
+			continue
+		case value.Kind() == reflect.Ptr && value.Type().Elem().Kind() == reflect.Struct:
+			// sections that are disabled when unset are only enabled by
+			// setting one of their settings
+			section := reflect.New(value.Type().Elem())
+			if !value.IsNil() {
+				section.Elem().Set(value.Elem())
+			}
+			nested, err := applyEnv(section.Elem(), name)
+			if err != nil {
+				return false, err
+			}
+			if nested {
+				value.Set(section)
+				set = true
+			}
+			continue
+		}
+
+		env := os.Getenv(name)
+		if env == "" {
+			continue
+		}
+		ok, err := setFromEnv(value, env)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s %q: %w", name, env, err)
+		}
+		set = set || ok
+	}
+	return set, nil
+}
+
+// setFromEnv parses the value of an environment variable into the field. It
+// returns false if the field's type can't be set from the environment.
+func setFromEnv(field reflect.Value, env string) (bool, error) {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(env)
+		if err != nil {
+			return false, err
+		}
+		field.SetInt(int64(d))
+		return true, nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(env)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(env)
+		if err != nil {
+			return false, err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(env)
+		if err != nil {
+			return false, err
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(env, 64)
+		if err != nil {
+			return false, err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return false, nil
+		}
+		values := strings.Split(env, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+		field.Set(reflect.ValueOf(values))
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// Validate checks that the config is complete and consistent
+func (cfg Config) Validate() error {
+	if cfg.Registry == "" {
+		return errors.New("no registry set")
+	}
+	// gitlab repos can be nested in subgroups
+	parts := strings.Split(cfg.Registry, "/")
+	valid := len(parts) == 2 || (len(parts) > 2 && cfg.GitLab != nil)
+	for _, part := range parts {
+		valid = valid && part != ""
+	}
+	if !valid {
+		return fmt.Errorf("invalid registry %q, expected owner/repo", cfg.Registry)
+	}
+	if cfg.Branch == "" {
+		return errors.New("no branch set")
+	}
+	if _, err := cron.ParseStandard(cfg.UpdateFrequency); err != nil {
+		return fmt.Errorf("invalid update frequency %q: %w", cfg.UpdateFrequency, err)
+	}
+	if cfg.UpdateJitter < 0 {
+		return fmt.Errorf("invalid update jitter %s", cfg.UpdateJitter)
+	}
+	if cfg.RequestTimeout < 0 {
+		return fmt.Errorf("invalid request timeout %s", cfg.RequestTimeout)
+	}
+	if l := cfg.Limits; l.ReadHeaderTimeout < 0 || l.ReadTimeout < 0 || l.WriteTimeout < 0 || l.IdleTimeout < 0 {
+		return errors.New("invalid limits, timeouts can't be negative")
+	}
+	if cfg.Limits.MaxHeaderBytes < 0 || cfg.Limits.MaxConns < 0 {
+		return errors.New("invalid limits, max_header_bytes and max_conns can't be negative")
+	}
+	if cfg.MaxStaleness < 0 {
+		return fmt.Errorf("invalid max staleness %s", cfg.MaxStaleness)
+	}
+	if cfg.InitialPull != nil && cfg.InitialPull.Timeout <= 0 {
+		return fmt.Errorf("invalid initial pull timeout %s", cfg.InitialPull.Timeout)
+	}
+	for key, scopes := range cfg.APIKeys {
+		if key == "" {
+			return errors.New("empty api key")
+		}
+		for _, scope := range scopes {
+			if scope != server.ScopeBulk && scope != server.ScopeAdmin && scope != server.ScopeReplication {
+				return fmt.Errorf("unknown scope %q for api key", scope)
+			}
+		}
+	}
+	if auth := cfg.AdminBasicAuth; auth != nil && (auth.Username == "" || auth.Password == "") {
+		return errors.New("admin basic auth requires a username and password")
+	}
+	for _, webhook := range append([]string{cfg.SlackWebhook, cfg.DiscordWebhook}, cfg.Webhooks...) {
+		if webhook == "" {
+			continue
+		}
+		if u, err := url.Parse(webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid webhook url %q", webhook)
+		}
+	}
+	sources := 0
+	for _, set := range []bool{cfg.GitLab != nil, cfg.Gitea != nil, cfg.S3 != nil, cfg.Dir != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return errors.New("only one of gitlab, gitea, s3 and dir can be set")
+	}
+	if sources > 0 && (cfg.Archive || cfg.GraphQL) {
+		return errors.New("archive and graphql can only be set for registries on github")
+	}
+	if cfg.Archive && cfg.GraphQL {
+		return errors.New("only one of archive and graphql can be set")
+	}
+	if cfg.GraphQL && cfg.GitHubToken == "" {
+		return errors.New("graphql requires a github token")
+	}
+	for _, forge := range []*ForgeConfig{cfg.GitLab, cfg.Gitea} {
+		if forge == nil {
+			continue
+		}
+		if u, err := url.Parse(forge.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid url %q", forge.URL)
+		}
+	}
+	if cfg.S3 != nil && cfg.Pin != "" {
+		return errors.New("a registry pulled from s3 can't be pinned")
+	}
+	if cfg.Dir != "" && cfg.Pin != "" {
+		return errors.New("a registry pulled from a directory can't be pinned")
+	}
+	if cfg.S3 != nil && cfg.Mirror != nil {
+		return errors.New("a registry pulled from s3 can't be mirrored")
+	}
+	if cfg.S3 != nil {
+		if u, err := url.Parse(cfg.S3.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid s3 endpoint %q", cfg.S3.Endpoint)
+		}
+		if cfg.S3.Bucket == "" {
+			return errors.New("no s3 bucket set")
+		}
+	}
+	if cfg.Postgres != "" && cfg.StoreFile != "" {
+		return errors.New("only one of postgres and store_file can be set")
+	}
+	if cfg.Redis != nil && cfg.Redis.URL == "" {
+		return errors.New("no redis url set")
+	}
+	if cfg.CoinGecko != nil {
+		if cfg.CoinGecko.URL != "" {
+			if u, err := url.Parse(cfg.CoinGecko.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				return fmt.Errorf("invalid coingecko url %q", cfg.CoinGecko.URL)
+			}
+		}
+		if cfg.CoinGecko.Interval < 0 {
+			return fmt.Errorf("invalid coingecko interval %s", cfg.CoinGecko.Interval)
+		}
+	}
+	if tls := cfg.TLS; tls != nil {
+		if (tls.CertFile == "") != (tls.KeyFile == "") {
+			return errors.New("tls requires both a cert_file and a key_file")
+		}
+		if (tls.CertFile != "") == (tls.ACME != nil) {
+			return errors.New("tls requires either a cert_file and key_file or acme")
+		}
+		if tls.ACME != nil {
+			if len(tls.ACME.Domains) == 0 {
+				return errors.New("no acme domains set")
+			}
+			if tls.ACME.CacheDir == "" {
+				return errors.New("no acme cache_dir set")
+			}
+			if tls.ACME.DirectoryURL != "" {
+				if u, err := url.Parse(tls.ACME.DirectoryURL); err != nil || u.Scheme != "https" {
+					return fmt.Errorf("invalid acme directory url %q", tls.ACME.DirectoryURL)
+				}
+			}
+		}
+	}
+	if p := cfg.Primary; p != nil {
+		if u, err := url.Parse(p.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid primary url %q", p.URL)
+		}
+		if cfg.Redis != nil {
+			return errors.New("only one of primary and redis can be set")
+		}
+	}
+	if lf := cfg.LogFile; lf != nil {
+		if lf.Path == "" {
+			return errors.New("the log file requires a path")
+		}
+		if lf.MaxSize < 0 || lf.MaxAge < 0 || lf.MaxBackups < 0 {
+			return errors.New("invalid log file, max_size, max_age and max_backups can't be negative")
+		}
+	}
+	if cfg.RateLimit != nil {
+		if cfg.RateLimit.Rate <= 0 {
+			return fmt.Errorf("invalid rate limit %v, expected a positive number of requests per second", cfg.RateLimit.Rate)
+		}
+		if cfg.RateLimit.Burst < 0 {
+			return fmt.Errorf("invalid rate limit burst %d", cfg.RateLimit.Burst)
+		}
+	}
+	return nil
+}
+
+// Options converts the config into the server's options
+func (cfg Config) Options() ([]server.Option, error) {
+	opts := []server.Option{
+		server.WithBranch(cfg.Branch),
+		server.WithUpdateJitter(cfg.UpdateJitter),
+		server.WithMaxStaleness(cfg.MaxStaleness),
+		server.WithRequestTimeout(cfg.RequestTimeout),
+		server.WithLimits(server.Limits(cfg.Limits)),
+	}
+	opts = append(opts, cfg.ReloadOptions()...)
+	if cfg.Import != "" {
+		snapshot, err := readSnapshot(cfg.Import)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, server.WithSnapshot(snapshot))
+	}
+	if cfg.UnixSocket != "" {
+		opts = append(opts, server.WithUnixSocket(cfg.UnixSocket))
+	}
+	if tls := cfg.TLS; tls != nil {
+		if acme := tls.ACME; acme != nil {
+			opts = append(opts, server.WithACME(server.ACME{
+				Domains:      acme.Domains,
+				Email:        acme.Email,
+				CacheDir:     acme.CacheDir,
+				DirectoryURL: acme.DirectoryURL,
+				HTTPAddr:     acme.HTTPAddr,
+			}))
+		} else {
+			opts = append(opts, server.WithTLS(tls.CertFile, tls.KeyFile))
+		}
+		if tls.AdminClientCA != "" {
+			opts = append(opts, server.WithAdminClientCA(tls.AdminClientCA))
+		}
+	}
+	if cfg.Archive {
+		opts = append(opts, server.WithArchive())
+	}
+	if cfg.GraphQL {
+		opts = append(opts, server.WithGraphQL())
+	}
+	if cfg.GitHubToken != "" {
+		opts = append(opts, server.WithGitHubToken(cfg.GitHubToken))
+	}
+	if cfg.UserAgent != "" {
+		opts = append(opts, server.WithHTTPClient(server.NewHTTPClient(cfg.UserAgent)))
+	}
+	ref := cfg.Branch
+	if cfg.Pin != "" {
+		ref = cfg.Pin
+		opts = append(opts, server.WithPin(cfg.Pin))
+	}
+	if gitlab := cfg.GitLab; gitlab != nil {
+		opts = append(opts, server.WithSource(server.GitLabSource{
+			URL:     strings.TrimSuffix(gitlab.URL, "/"),
+			Project: cfg.Registry,
+			Branch:  ref,
+			Token:   gitlab.Token,
+		}))
+	}
+	if gitea := cfg.Gitea; gitea != nil {
+		opts = append(opts, server.WithSource(server.GiteaSource{
+			URL:    strings.TrimSuffix(gitea.URL, "/"),
+			Repo:   cfg.Registry,
+			Branch: ref,
+			Token:  gitea.Token,
+		}))
+	}
+	if s3 := cfg.S3; s3 != nil {
+		source := server.S3Source{
+			Endpoint:        s3.Endpoint,
+			Bucket:          s3.Bucket,
+			Prefix:          s3.Prefix,
+			Region:          s3.Region,
+			AccessKeyID:     s3.AccessKeyID,
+			SecretAccessKey: s3.SecretAccessKey,
+		}
+		if source.Region == "" {
+			source.Region = "us-east-1"
+		}
+		if source.AccessKeyID == "" && source.SecretAccessKey == "" {
+			source.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+			source.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		}
+		opts = append(opts, server.WithSource(source))
+	}
+	if cfg.Dir != "" {
+		opts = append(opts, server.WithSource(server.DirSource{Root: cfg.Dir}))
+	}
+	if cfg.GenesisDir != "" {
+		opts = append(opts, server.WithGenesisDir(cfg.GenesisDir))
+	}
+	if cfg.Mirror != nil {
+		opts = append(opts, server.WithMirror(cfg.Mirror.Dir))
+	}
+	if p := cfg.InitialPull; p != nil {
+		opts = append(opts, server.WithReadyAfterPull(p.Timeout, p.Fallback))
+	}
+	if cfg.Directory {
+		opts = append(opts, server.WithDirectory())
+	}
+	if cfg.SigningKey != "" {
+		opts = append(opts, server.WithSigningKey(cfg.SigningKey))
+	}
+	if cfg.Postgres != "" {
+		store, err := server.NewPostgresStore(cfg.Postgres, cfg.Registry)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to postgres: %w", err)
+		}
+		opts = append(opts, server.WithStore(store))
+	}
+	if cfg.StoreFile != "" {
+		opts = append(opts, server.WithStore(server.FileStore{Path: cfg.StoreFile}))
+	}
+	if cfg.Redis != nil {
+		prefix := cfg.Redis.Prefix
+		if prefix == "" {
+			prefix = server.DefaultRedisPrefix
+		}
+		store, err := server.NewRedisStore(cfg.Redis.URL, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis url: %w", err)
+		}
+		opts = append(opts, server.WithSharedStore(store))
+	}
+	if p := cfg.Primary; p != nil {
+		opts = append(opts, server.WithSharedStore(server.NewPrimary(p.URL, p.APIKey)))
+	}
+	if len(cfg.APIKeys) > 0 {
+		opts = append(opts, server.WithAPIKeys(server.APIKeys(cfg.APIKeys)))
+	}
+	if auth := cfg.AdminBasicAuth; auth != nil {
+		opts = append(opts, server.WithAdminBasicAuth(auth.Username, auth.Password))
+	}
+	if cg := cfg.CoinGecko; cg != nil {
+		provider := server.CoinGecko{URL: strings.TrimSuffix(cg.URL, "/"), APIKey: cg.APIKey}
+		opts = append(opts, server.WithPrices(provider, cg.Interval))
+	}
+	if ic := cfg.ImageChecks; ic != nil {
+		opts = append(opts, server.WithImageChecks(ic.Interval))
+	}
+	return opts, nil
+}
+
+// ReloadOptions returns the options of the settings that can be changed while
+// the server is running, see server.WithReload
+func (cfg Config) ReloadOptions() []server.Option {
+	opts := []server.Option{server.WithPullInterval(cfg.UpdateFrequency)}
+	for _, webhook := range cfg.Webhooks {
+		opts = append(opts, server.WithNotifiers(server.Webhook{URL: webhook}))
+	}
+	if cfg.SlackWebhook != "" {
+		opts = append(opts, server.WithNotifiers(server.Slack{WebhookURL: cfg.SlackWebhook}))
+	}
+	if cfg.DiscordWebhook != "" {
+		opts = append(opts, server.WithNotifiers(server.Discord{WebhookURL: cfg.DiscordWebhook}))
+	}
+	if len(cfg.Chains.Allow) > 0 || len(cfg.Chains.Deny) > 0 {
+		opts = append(opts, server.WithChainFilter(cfg.Chains.Allow, cfg.Chains.Deny))
+	}
+	if len(cfg.Aliases) > 0 {
+		opts = append(opts, server.WithAliases(cfg.Aliases))
+	}
+	if len(cfg.PreviousChainIDs) > 0 {
+		opts = append(opts, server.WithPreviousChainIDs(cfg.PreviousChainIDs))
+	}
+	if rl := cfg.RateLimit; rl != nil {
+		burst := rl.Burst
+		if burst == 0 {
+			burst = int(rl.Rate)
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		opts = append(opts, server.WithRateLimiter(server.NewRateLimiter(rl.Rate, burst, rl.TrustProxy, rl.Allowlist)))
+	}
+	return opts
+}
+
+func readSnapshot(path string) (server.Snapshot, error) {
+	var snapshot server.Snapshot
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot, fmt.Errorf("reading snapshot: %w", err)
+	}
+	if err := json.Unmarshal(bz, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+	return snapshot, nil
+}
@@ -2,48 +2,275 @@ package main
 
 import (
 	"context"
-	"errors"
+	"flag"
 	"fmt"
+	"log"
+	"net"
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/cmwaters/skychart/server"
 )
 
 const (
 	defaultUpdateFreq = "@daily"
+
+	renderUsage = "\n\nUsage: skychart render dir [flags] [registry-url]"
+
+	usage = "\n\nUsage: skychart [--config file] [--import snapshot] [--registry owner/repo] [--listen addr] [--port port] [--pull-interval interval] [registry-url] [listen-addr]"
 )
 
 func main() {
-	registryUrl, listenAddr, err := parseArgs()
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		if err := render(os.Args[2:]); err != nil {
+			fmt.Print(err)
+		}
+		return
+	}
+
+	cfg, err := parseArgs(os.Args[1:])
 	if err != nil {
 		fmt.Print(err)
+		return
+	}
+
+	if lf := cfg.LogFile; lf != nil {
+		file := &server.LogFile{
+			Path:       lf.Path,
+			MaxSize:    int64(lf.MaxSize) << 20,
+			MaxAge:     lf.MaxAge,
+			MaxBackups: lf.MaxBackups,
+			Compress:   lf.Compress,
+		}
+		defer file.Close()
+		log.SetOutput(file)
 	}
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer cancel()
 
-	err = server.Serve(ctx, registryUrl, listenAddr, defaultUpdateFreq)
+	opts, err := cfg.Options()
+	if err != nil {
+		fmt.Print(err)
+		return
+	}
+
+	// SIGHUP and POST /admin/reload reread the config file and environment
+	opts = append(opts, server.WithReload(func() ([]server.Option, error) {
+		cfg, err := parseArgs(os.Args[1:])
+		if err != nil {
+			return nil, err
+		}
+		return cfg.ReloadOptions(), nil
+	}))
+
+	err = server.Serve(ctx, cfg.Registry, cfg.ListenAddr, cfg.UpdateFrequency, opts...)
 	if err != nil {
 		fmt.Print(err)
 	}
 }
 
-func parseArgs() (string, string, error) {
-	if len(os.Args) > 3 || len(os.Args) == 1 {
-		return "", "", errors.New("expected 1 or 2 arguments. \n\nUsage: skychart registry-url [listen-addr]")
+// render pulls the registry once and renders every query of it as a JSON file
+// into the directory given by the first argument. The other arguments are
+// those of the server.
+func render(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no output directory given.%s", renderUsage)
+	}
+	dir := args[0]
+	cfg, err := parseArgs(args[1:])
+	if err != nil {
+		return err
 	}
-	registryUrl := os.Args[1]
-	_, err := url.Parse(registryUrl)
+	// every query is made by the same client, and the registry must be
+	// pulled before it is rendered
+	cfg.RateLimit = nil
+	cfg.InitialPull = nil
+	opts, err := cfg.Options()
 	if err != nil {
-		return "", "", fmt.Errorf("unable to parse registry url: %w. \n\nUsage: skychart registry-url [listen-addr]", err)
+		return err
+	}
+	opts = append([]server.Option{server.WithRegistry(cfg.Registry), server.WithPullInterval(cfg.UpdateFrequency)}, opts...)
+	srv, err := server.New(opts...)
+	if err != nil {
+		return err
 	}
 
-	if len(os.Args) == 2 {
-		return registryUrl, "", nil
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+	if err := srv.Start(ctx); err != nil {
+		return err
+	}
+	defer srv.Stop()
+
+	n, err := srv.Render(dir)
+	if err != nil {
+		return err
+	}
+	log.Printf("rendered %d queries into %s", n, dir)
+	return nil
+}
+
+// parseArgs builds the config from the config file, if any, and then overrides
+// it with the environment, the flags and the positional arguments
+func parseArgs(args []string) (Config, error) {
+	fs := flag.NewFlagSet("skychart", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to a YAML config file")
+	importPath := fs.String("import", "", "path to a registry snapshot to seed the server with")
+	registry := fs.String("registry", "", "github repo of the chain-registry, e.g. cosmos/chain-registry")
+	listen := fs.String("listen", "", "address the server listens on, e.g. :8080")
+	port := fs.String("port", "", "port the server listens on, overriding the port of the listen address")
+	pullInterval := fs.String("pull-interval", "", "how often the registry is pulled, as a cron spec or a duration such as 1h")
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+	if fs.NArg() > 2 {
+		return Config{}, fmt.Errorf("expected at most 2 arguments.%s", usage)
+	}
+
+	cfg := DefaultConfig()
+	if *configPath != "" {
+		var err error
+		cfg, err = LoadConfig(*configPath)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	if err := parseEnv(&cfg); err != nil {
+		return cfg, err
+	}
+	if *importPath != "" {
+		cfg.Import = *importPath
+	}
+	if *registry != "" {
+		cfg.Registry = *registry
+	}
+	if *listen != "" {
+		cfg.ListenAddr = *listen
+	}
+	if *port != "" {
+		cfg.ListenAddr = withPort(cfg.ListenAddr, *port)
+	}
+	if *pullInterval != "" {
+		cfg.UpdateFrequency = parsePullInterval(*pullInterval)
 	}
 
-	return registryUrl, os.Args[2], nil
+	if fs.NArg() > 0 {
+		cfg.Registry = fs.Arg(0)
+		_, err := url.Parse(cfg.Registry)
+		if err != nil {
+			return cfg, fmt.Errorf("unable to parse registry url: %w.%s", err, usage)
+		}
+	}
+	if fs.NArg() > 1 {
+		cfg.ListenAddr = fs.Arg(1)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid config: %w.%s", err, usage)
+	}
+	return cfg, nil
+}
+
+// withPort replaces the port of the listen address, keeping its host
+func withPort(listenAddr, port string) string {
+	host, _, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		host = ""
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// parsePullInterval turns a duration such as 30m into the equivalent cron
+// spec. Anything else is taken to be a cron spec.
+func parsePullInterval(interval string) string {
+	if d, err := time.ParseDuration(interval); err == nil && d > 0 {
+		return "@every " + d.String()
+	}
+	return interval
+}
+
+// parseEnv overrides the config with the environment variable of each
+// setting, see Config.ApplyEnv, and then with the shorthands SKYCHART_PORT,
+// SKYCHART_PULL_INTERVAL, SKYCHART_API_KEYS, SKYCHART_ADMIN_USERNAME,
+// SKYCHART_ADMIN_PASSWORD, SKYCHART_RATE_LIMIT and GITHUB_TOKEN
+func parseEnv(cfg *Config) error {
+	if err := cfg.ApplyEnv(); err != nil {
+		return err
+	}
+	if port := os.Getenv("SKYCHART_PORT"); port != "" {
+		cfg.ListenAddr = withPort(cfg.ListenAddr, port)
+	}
+	if interval := os.Getenv("SKYCHART_PULL_INTERVAL"); interval != "" {
+		cfg.UpdateFrequency = parsePullInterval(interval)
+	}
+
+	// GITHUB_TOKEN is often set for other tools, so SKYCHART_GITHUB_TOKEN
+	// takes precedence
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && os.Getenv("SKYCHART_GITHUB_TOKEN") == "" {
+		cfg.GitHubToken = token
+	}
+
+	if keys := os.Getenv("SKYCHART_API_KEYS"); keys != "" {
+		apiKeys, err := parseAPIKeys(keys)
+		if err != nil {
+			return err
+		}
+		cfg.APIKeys = apiKeys
+	}
+
+	username, password := os.Getenv("SKYCHART_ADMIN_USERNAME"), os.Getenv("SKYCHART_ADMIN_PASSWORD")
+	if username != "" || password != "" {
+		cfg.AdminBasicAuth = &BasicAuthConfig{Username: username, Password: password}
+	}
+
+	if limit := os.Getenv("SKYCHART_RATE_LIMIT"); limit != "" {
+		rl, err := parseRateLimit(limit)
+		if err != nil {
+			return err
+		}
+		cfg.RateLimit = rl
+	}
+	return nil
+}
+
+// parseAPIKeys parses keys of the form "key1=scope1,scope2;key2=scope1"
+func parseAPIKeys(keys string) (map[string][]string, error) {
+	apiKeys := make(map[string][]string)
+	for _, entry := range strings.Split(keys, ";") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid api key entry %q, expected key=scope1,scope2", entry)
+		}
+		apiKeys[parts[0]] = strings.Split(parts[1], ",")
+	}
+	return apiKeys, nil
+}
+
+// parseRateLimit builds the rate limit config from the requests per second
+// limit and the optional SKYCHART_RATE_LIMIT_BURST, SKYCHART_RATE_LIMIT_ALLOWLIST
+// and SKYCHART_TRUST_PROXY environment variables
+func parseRateLimit(limit string) (*RateLimitConfig, error) {
+	rate, err := strconv.ParseFloat(limit, 64)
+	if err != nil || rate <= 0 {
+		return nil, fmt.Errorf("invalid rate limit %q, expected a positive number of requests per second", limit)
+	}
+	rl := &RateLimitConfig{Rate: rate}
+	if b := os.Getenv("SKYCHART_RATE_LIMIT_BURST"); b != "" {
+		rl.Burst, err = strconv.Atoi(b)
+		if err != nil || rl.Burst < 1 {
+			return nil, fmt.Errorf("invalid rate limit burst %q", b)
+		}
+	}
+	if a := os.Getenv("SKYCHART_RATE_LIMIT_ALLOWLIST"); a != "" {
+		rl.Allowlist = strings.Split(a, ",")
+	}
+	rl.TrustProxy = os.Getenv("SKYCHART_TRUST_PROXY") == "true"
+	return rl, nil
 }
@@ -0,0 +1,3 @@
+package skychartpb
+
+//go:generate protoc -I.. --go_out=. --go_opt=paths=source_relative ../skychart.proto
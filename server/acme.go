@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// LetsEncryptURL is the directory of Let's Encrypt's production ACME server
+const LetsEncryptURL = acme.LetsEncryptURL
+
+// ACME obtains and renews a certificate for the domains from an ACME server,
+// such as Let's Encrypt, using the http-01 challenge. The challenge is served
+// on HTTPAddr, which must be reachable on port 80 of every domain.
+type ACME struct {
+	Domains []string
+	// Email is the contact of the account, notified of expiring certificates
+	Email string
+	// CacheDir keeps the account key and the certificate across restarts
+	CacheDir string
+	// DirectoryURL of the ACME server. Defaults to Let's Encrypt.
+	DirectoryURL string
+	// HTTPAddr serves the challenges and redirects everything else to https.
+	// Defaults to :80.
+	HTTPAddr string
+	// Client makes the requests. Defaults to a client shared by skychart.
+	Client *http.Client
+}
+
+// manager returns the autocert manager that obtains the certificate on the
+// first handshake and renews it a month before it expires
+func (a ACME) manager() *autocert.Manager {
+	directory := a.DirectoryURL
+	if directory == "" {
+		directory = LetsEncryptURL
+	}
+	return &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(a.CacheDir),
+		HostPolicy: autocert.HostWhitelist(a.Domains...),
+		Email:      a.Email,
+		Client:     &acme.Client{DirectoryURL: directory, HTTPClient: a.Client},
+	}
+}
+
+// httpAddr returns the address the challenges are served on
+func (a ACME) httpAddr() string {
+	if a.HTTPAddr == "" {
+		return ":80"
+	}
+	return a.HTTPAddr
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// SetAliases sets alternative names for chains and assets, keyed by the chain
+// name or asset display name, e.g. {"cosmoshub": ["cosmos", "gaia"]}. Aliases
+// take effect from the next pull.
+func (h *Handler) SetAliases(aliases map[string][]string) {
+	h.aliases = aliases
+}
+
+// indexAliases adds the configured aliases and the names given by the registry,
+// the pretty name of a chain and the name and symbol of an asset, to the
+// lookup indexes. Aliases never shadow the name or ID of another chain or
+// asset, and configured aliases take precedence over the registry's names.
+// The caller must hold the write lock.
+func (h *Handler) indexAliases() {
+	h.chainAliases = make(map[string][]string)
+	h.assetAliases = make(map[string][]string)
+	addChain := func(name, alias string) {
+		key := strings.ToLower(alias)
+		if _, ok := h.chainByKey[key]; ok || key == "" {
+			return
+		}
+		h.chainByKey[key] = name
+		h.chainAliases[name] = append(h.chainAliases[name], alias)
+	}
+	addAsset := func(display, alias string) {
+		key := strings.ToLower(alias)
+		if _, ok := h.assetByKey[key]; ok || key == "" {
+			return
+		}
+		h.assetByKey[key] = display
+		h.assetAliases[display] = append(h.assetAliases[display], alias)
+	}
+
+	// iterate in a fixed order so that clashing aliases always resolve the same
+	names := make([]string, 0, len(h.aliases))
+	for name := range h.aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, alias := range h.aliases[name] {
+			if _, ok := h.chainList[name]; ok {
+				addChain(name, alias)
+			}
+			if _, ok := h.chainByAsset[name]; ok {
+				addAsset(name, alias)
+			}
+		}
+	}
+	for _, name := range h.chains {
+		if pretty := h.chainList[name].PrettyName; pretty != nil {
+			addChain(name, *pretty)
+		}
+	}
+	for _, display := range h.assets {
+		asset, _ := h.findAsset(display)
+		if asset.Name != nil {
+			addAsset(display, *asset.Name)
+		}
+		if asset.Symbol != nil {
+			addAsset(display, *asset.Symbol)
+		}
+	}
+
+	for _, aliases := range h.chainAliases {
+		sort.Strings(aliases)
+	}
+	for _, aliases := range h.assetAliases {
+		sort.Strings(aliases)
+	}
+}
+
+// Aliases returns the alternative names of every chain and asset that has any
+func (h *Handler) Aliases(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	h.respond(res, req, types.Aliases{Chains: h.chainAliases, Assets: h.assetAliases})
+}
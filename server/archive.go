@@ -0,0 +1,133 @@
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// archive holds the chain files of the registry, read from a single tarball
+// of the repo. It is downloaded on first use, so a pull needs only one
+// request for the files instead of two per chain.
+type archive struct {
+	mtx    sync.Mutex
+	loaded bool
+	dirs   []string
+	files  map[string][]byte // chain/file -> contents
+}
+
+// SetArchive pulls the registry by downloading a tarball of the repo rather
+// than fetching each file separately. It has no effect if a source is set.
+func (h *Handler) SetArchive(enabled bool) {
+	h.archive = enabled
+}
+
+// load downloads and unpacks the tarball of the github repo if it hasn't been
+// loaded yet
+func (a *archive) load(ctx context.Context, g githubSource) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.loaded {
+		return nil
+	}
+
+	tarball, err := g.tarball(ctx, g.branch)
+	if err != nil {
+		return err
+	}
+	defer tarball.Close()
+
+	dirs, files, err := readArchive(tarball)
+	if err != nil {
+		return fmt.Errorf("reading tarball of %s: %w", g.branch, err)
+	}
+	a.dirs, a.files, a.loaded = dirs, files, true
+	return nil
+}
+
+// Tree writes every file of the repo at the commit into dir
+func (g githubSource) Tree(ctx context.Context, commit, dir string) error {
+	tarball, err := g.tarball(ctx, commit)
+	if err != nil {
+		return err
+	}
+	defer tarball.Close()
+	return extractTarball(tarball, dir)
+}
+
+// tarball streams a gzipped tarball of the repo at the ref
+func (g githubSource) tarball(ctx context.Context, ref string) (io.ReadCloser, error) {
+	query := fmt.Sprintf("%s/repos/%s/tarball/%s", g.apiUrl, g.repo, ref)
+	if err := g.quota.throttle(ctx, query); err != nil {
+		return nil, err
+	}
+	// github redirects to the codeload host, which the client follows
+	tarball, found, err := stream(ctx, g.client, g.header(), query, g.quota.observe)
+	if err == nil && !found {
+		err = fmt.Errorf("no tarball of %s at %s", g.repo, ref)
+	}
+	return tarball, err
+}
+
+// readArchive reads the top level directories and the chain.json and
+// assetlist.json files of each from a gzipped tarball. Github nests the repo
+// in a directory named after the commit, which is stripped.
+func readArchive(r io.Reader) ([]string, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gz.Close()
+
+	dirs := make([]string, 0)
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		parts := strings.Split(strings.Trim(header.Name, "/"), "/")
+		if len(parts) < 2 {
+			continue
+		}
+		parts = parts[1:]
+		switch {
+		case header.Typeflag == tar.TypeDir && len(parts) == 1:
+			dirs = append(dirs, parts[0])
+		case header.Typeflag == tar.TypeReg && len(parts) == 2 && (parts[1] == "chain.json" || parts[1] == "assetlist.json"):
+			bz, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, nil, err
+			}
+			files[path.Join(parts...)] = bz
+		}
+	}
+	sort.Strings(dirs)
+	return dirs, files, nil
+}
+
+func (a *archive) chains() []string {
+	chains := make([]string, 0, len(a.dirs))
+	for _, dir := range a.dirs {
+		if isChainDir(dir) {
+			chains = append(chains, dir)
+		}
+	}
+	return chains
+}
+
+func (a *archive) file(chain, file string) ([]byte, bool) {
+	bz, ok := a.files[chain+"/"+file]
+	return bz, ok
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"testing"
+)
+
+func TestReadArchive(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	entries := []struct {
+		name string
+		dir  bool
+		body string
+	}{
+		{name: "cosmos-chain-registry-abc1234/", dir: true},
+		{name: "cosmos-chain-registry-abc1234/README.md", body: "readme"},
+		{name: "cosmos-chain-registry-abc1234/osmosis/", dir: true},
+		{name: "cosmos-chain-registry-abc1234/osmosis/chain.json", body: `{"chain_name":"osmosis"}`},
+		{name: "cosmos-chain-registry-abc1234/osmosis/assetlist.json", body: `{"chain_name":"osmosis"}`},
+		{name: "cosmos-chain-registry-abc1234/osmosis/images/", dir: true},
+		{name: "cosmos-chain-registry-abc1234/osmosis/images/osmo.png", body: "png"},
+		{name: "cosmos-chain-registry-abc1234/osmosis/versions.json", body: "{}"},
+		{name: "cosmos-chain-registry-abc1234/cosmoshub/", dir: true},
+		{name: "cosmos-chain-registry-abc1234/cosmoshub/chain.json", body: `{"chain_name":"cosmoshub"}`},
+	}
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0o644, Typeflag: tar.TypeReg, Size: int64(len(entry.body))}
+		if entry.dir {
+			header.Typeflag, header.Size = tar.TypeDir, 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(entry.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dirs, files, err := readArchive(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"cosmoshub", "osmosis"}; !reflect.DeepEqual(dirs, want) {
+		t.Errorf("dirs %v, want %v", dirs, want)
+	}
+	want := map[string][]byte{
+		"osmosis/chain.json":     []byte(`{"chain_name":"osmosis"}`),
+		"osmosis/assetlist.json": []byte(`{"chain_name":"osmosis"}`),
+		"cosmoshub/chain.json":   []byte(`{"chain_name":"cosmoshub"}`),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("files %q, want %q", files, want)
+	}
+}
+
+func TestReadArchiveInvalid(t *testing.T) {
+	if _, _, err := readArchive(bytes.NewReader([]byte("not a tarball"))); err == nil {
+		t.Error("expected an error for a body that isn't gzipped")
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// nativeAssetType is accepted by ?type= for the native coins of a chain
+const nativeAssetType = "native"
+
+// assetType returns the type of an asset: its kind, which newer registries
+// call type_asset, or otherwise ics20 for assets transferred over IBC and
+// sdk.coin for the rest
+func assetType(asset types.AssetElement) string {
+	switch {
+	case asset.Kind != nil && *asset.Kind != "":
+		return strings.ToLower(string(*asset.Kind))
+	case asset.Ibc != nil:
+		return string(types.Ics20)
+	default:
+		return string(types.SDKCoin)
+	}
+}
+
+// assetTypeFilter returns the asset types in the comma separated ?type= of
+// the request, where native stands for sdk.coin. It returns false if the
+// request doesn't filter by type.
+func assetTypeFilter(req *http.Request) (map[string]bool, bool) {
+	var filter map[string]bool
+	for _, assetType := range strings.Split(req.URL.Query().Get("type"), ",") {
+		assetType = strings.ToLower(strings.TrimSpace(assetType))
+		if assetType == "" {
+			continue
+		}
+		if assetType == nativeAssetType {
+			assetType = string(types.SDKCoin)
+		}
+		if filter == nil {
+			filter = make(map[string]bool)
+		}
+		filter[assetType] = true
+	}
+	return filter, filter != nil
+}
+
+// filterAssetList returns the asset list with only the assets of the types
+func filterAssetList(assetList types.AssetList, filter map[string]bool) types.AssetList {
+	assets := make([]types.AssetElement, 0, len(assetList.Assets))
+	for _, asset := range assetList.Assets {
+		if filter[assetType(asset)] {
+			assets = append(assets, asset)
+		}
+	}
+	return types.AssetList{ChainID: assetList.ChainID, Assets: assets}
+}
+
+// filterAssetLists returns the asset lists with only the assets of the types
+func filterAssetLists(assetLists map[string]types.AssetList, filter map[string]bool) map[string]types.AssetList {
+	filtered := make(map[string]types.AssetList, len(assetLists))
+	for name, assetList := range assetLists {
+		filtered[name] = filterAssetList(assetList, filter)
+	}
+	return filtered
+}
+
+// assetsOfType returns the display names that any chain registers an asset of
+// the types under. The caller must hold the read lock.
+func (h *Handler) assetsOfType(names []string, filter map[string]bool) []string {
+	matches := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, chain := range h.assetChains[name] {
+			if asset, ok := h.chainAsset(chain, name); ok && filter[assetType(asset)] {
+				matches = append(matches, name)
+				break
+			}
+		}
+	}
+	return matches
+}
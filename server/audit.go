@@ -0,0 +1,124 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// maxAuditEntries is the number of admin actions kept in the audit log
+const maxAuditEntries = 1000
+
+// auditLog holds the most recent admin actions, oldest first
+type auditLog struct {
+	mtx     sync.Mutex
+	entries []types.AuditEntry
+}
+
+func newAuditLog() *auditLog {
+	return &auditLog{}
+}
+
+func (a *auditLog) record(entry types.AuditEntry) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.entries = append(a.entries, entry)
+	if len(a.entries) > maxAuditEntries {
+		a.entries = a.entries[len(a.entries)-maxAuditEntries:]
+	}
+}
+
+// since returns the entries recorded after the time, newest first
+func (a *auditLog) since(since time.Time) []types.AuditEntry {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	entries := make([]types.AuditEntry, 0)
+	for i := len(a.entries) - 1; i >= 0 && a.entries[i].Timestamp.After(since); i-- {
+		entries = append(entries, a.entries[i])
+	}
+	return entries
+}
+
+// Audit is middleware that records the requests to the admin routes that
+// change the state of the server, e.g. imports and maintenance toggles, along
+// with who made them and how they were answered. It must run before the
+// authentication so that refused requests are recorded too.
+func (h *Handler) Audit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet || req.Method == http.MethodHead {
+			next.ServeHTTP(res, req)
+			return
+		}
+		recorder := &statusRecorder{ResponseWriter: res, status: http.StatusOK}
+		next.ServeHTTP(recorder, req)
+		h.audit.record(types.AuditEntry{
+			Timestamp: time.Now(),
+			Principal: principal(req),
+			Action:    req.Method + " " + req.URL.Path,
+			Status:    recorder.status,
+			Outcome:   outcome(recorder.status),
+		})
+	})
+}
+
+// AuditLog returns the recorded admin actions, newest first. The optional
+// since parameter, a unix timestamp or RFC 3339 time, limits them to the
+// actions after it.
+func (h *Handler) AuditLog(res http.ResponseWriter, req *http.Request) {
+	var since time.Time
+	if value := req.URL.Query().Get("since"); value != "" {
+		var err error
+		if since, err = parseTime(value); err != nil {
+			badRequest(res)
+			return
+		}
+	}
+	respondWithJSON(res, h.audit.since(since))
+}
+
+// principal identifies who made the request from the credentials it carries.
+// API keys are identified by a fingerprint so that the log doesn't leak them.
+func principal(req *http.Request) string {
+	if username, _, ok := req.BasicAuth(); ok {
+		return "user:" + username
+	}
+	if key := apiKey(req); key != "" {
+		return keyFingerprint(key)
+	}
+	if req.TLS != nil && len(req.TLS.VerifiedChains) > 0 {
+		return "cert:" + req.TLS.VerifiedChains[0][0].Subject.CommonName
+	}
+	return "anonymous"
+}
+
+// keyFingerprint identifies the API key without revealing it
+func keyFingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "key:" + hex.EncodeToString(sum[:4])
+}
+
+func outcome(status int) string {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return "denied"
+	case status >= 400:
+		return "failed"
+	default:
+		return "succeeded"
+	}
+}
+
+// statusRecorder records the status code of the response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// ScopeBulk grants access to endpoints that aggregate data across many
+	// chains such as the relayer config generation
+	ScopeBulk = "bulk"
+	// ScopeAdmin grants access to endpoints that manage the server
+	ScopeAdmin = "admin"
+	// ScopeReplication grants access to the snapshots that servers following
+	// this one load the registry from
+	ScopeReplication = "replication"
+
+	apiKeyHeader = "X-API-Key"
+	apiKeyParam  = "api_key"
+)
+
+// APIKeys maps each API key to the scopes it is granted
+type APIKeys map[string][]string
+
+// RequireScope returns a middleware that only lets through requests carrying
+// an API key with the given scope. The key can be provided either through the
+// X-API-Key header or the api_key query parameter. If no keys are configured,
+// authentication is disabled and all requests are let through.
+func (keys APIKeys) RequireScope(scope string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			if len(keys) == 0 {
+				next.ServeHTTP(res, req)
+				return
+			}
+
+			scopes, ok := keys.lookup(apiKey(req))
+			if !ok {
+				unauthorized(res)
+				return
+			}
+			for _, s := range scopes {
+				if s == scope {
+					next.ServeHTTP(res, req)
+					return
+				}
+			}
+			forbidden(res)
+		})
+	}
+}
+
+// lookup returns the scopes of the key. The hashes of the key and of every
+// configured key are compared in constant time, so that neither a map lookup
+// nor an early return reveals how much of a key a guess got right.
+func (keys APIKeys) lookup(key string) ([]string, bool) {
+	hash := sha256.Sum256([]byte(key))
+	var scopes []string
+	found := false
+	for k, s := range keys {
+		h := sha256.Sum256([]byte(k))
+		if subtle.ConstantTimeCompare(hash[:], h[:]) == 1 {
+			scopes, found = s, true
+		}
+	}
+	return scopes, found
+}
+
+// apiKey returns the API key of the request, if any
+func apiKey(req *http.Request) string {
+	if key := req.Header.Get(apiKeyHeader); key != "" {
+		return key
+	}
+	return req.URL.Query().Get(apiKeyParam)
+}
+
+// BasicAuth is the username and password of HTTP basic authentication
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// valid reports whether the request carries the username and password
+func (b BasicAuth) valid(req *http.Request) bool {
+	username, password, ok := req.BasicAuth()
+	if !ok {
+		return false
+	}
+	// both are compared so that the time taken doesn't reveal which differs
+	usernameOk := subtle.ConstantTimeCompare([]byte(username), []byte(b.Username)) == 1
+	passwordOk := subtle.ConstantTimeCompare([]byte(password), []byte(b.Password)) == 1
+	return usernameOk && passwordOk
+}
+
+// requireAdmin is middleware that lets through requests with the admin basic
+// auth credentials or an API key with the admin scope, whichever are set
+func (o options) requireAdmin(next http.Handler) http.Handler {
+	keyed := o.apiKeys.RequireScope(ScopeAdmin)(next)
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if b := o.adminBasic; b != nil {
+			if b.valid(req) {
+				next.ServeHTTP(res, req)
+				return
+			}
+			if len(o.apiKeys) == 0 || apiKey(req) == "" {
+				res.Header().Set("WWW-Authenticate", `Basic realm="skychart admin"`)
+				unauthorized(res)
+				return
+			}
+		}
+		keyed.ServeHTTP(res, req)
+	})
+}
+
+// RequireClientCert is middleware that only lets through requests over TLS
+// with a client certificate that the server verified
+func RequireClientCert(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 {
+			unauthorized(res)
+			return
+		}
+		next.ServeHTTP(res, req)
+	})
+}
+
+func unauthorized(w http.ResponseWriter) {
+	setCORSHeaders(w)
+	w.WriteHeader(http.StatusUnauthorized)
+}
+
+func forbidden(w http.ResponseWriter) {
+	setCORSHeaders(w)
+	w.WriteHeader(http.StatusForbidden)
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireScope(t *testing.T) {
+	keys := APIKeys{"secret": {ScopeBulk}, "operator": {ScopeAdmin}}
+	handler := keys.RequireScope(ScopeBulk)(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {}))
+	for key, want := range map[string]int{
+		"":         http.StatusUnauthorized,
+		"secre":    http.StatusUnauthorized,
+		"secrets":  http.StatusUnauthorized,
+		"operator": http.StatusForbidden,
+		"secret":   http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(apiKeyHeader, key)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("key %q: status %d, want %d", key, rec.Code, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// BankMetadata returns the assets of a chain as x/bank denom metadata, ready
+// to be added to the denom_metadata of the bank module's genesis. Assets held
+// by token contracts, such as cw20s, have no bank denom and are left out.
+func (h *Handler) BankMetadata(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	exists, assetList := h.findAssetList(mux.Vars(req)["chain"])
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	metadata := make([]types.BankMetadata, 0, len(assetList.Assets))
+	for _, asset := range assetList.Assets {
+		if asset.Kind != nil && (*asset.Kind == types.Cw20 || *asset.Kind == types.Erc20 || *asset.Kind == types.Snip20) {
+			continue
+		}
+		metadata = append(metadata, bankMetadata(asset))
+	}
+	h.respond(res, req, metadata)
+}
+
+// bankMetadata converts an asset into x/bank denom metadata. The bank module
+// requires a name and symbol, so they default to the display denom.
+func bankMetadata(asset types.AssetElement) types.BankMetadata {
+	metadata := types.BankMetadata{
+		DenomUnits: make([]types.BankDenomUnit, 0, len(asset.DenomUnits)),
+		Base:       asset.Base,
+		Display:    asset.Display,
+		Name:       asset.Display,
+		Symbol:     strings.ToUpper(asset.Display),
+	}
+	for _, unit := range asset.DenomUnits {
+		aliases := unit.Aliases
+		if aliases == nil {
+			aliases = []string{}
+		}
+		metadata.DenomUnits = append(metadata.DenomUnits, types.BankDenomUnit{
+			Denom:    unit.Denom,
+			Exponent: unit.Exponent,
+			Aliases:  aliases,
+		})
+	}
+	if asset.Description != nil {
+		metadata.Description = *asset.Description
+	}
+	if asset.Name != nil && *asset.Name != "" {
+		metadata.Name = *asset.Name
+	}
+	if asset.Symbol != nil && *asset.Symbol != "" {
+		metadata.Symbol = *asset.Symbol
+	}
+	if asset.LogoURIs != nil {
+		if asset.LogoURIs.PNG != nil {
+			metadata.URI = *asset.LogoURIs.PNG
+		} else if asset.LogoURIs.SVG != nil {
+			metadata.URI = *asset.LogoURIs.SVG
+		}
+	}
+	return metadata
+}
@@ -0,0 +1,239 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// maxBatchSize bounds the number of chains or queries of a batch request
+	maxBatchSize = 100
+	// maxBatchBody bounds the size of the body of a batch request
+	maxBatchBody = 64 << 10
+	// maxBatchWorkers bounds the number of queries of a batch served at once
+	maxBatchWorkers = 8
+	// maxBatchResponse bounds the total size of the responses to the queries
+	// of a batch
+	maxBatchResponse = 4 << 20
+)
+
+// ChainBatch returns several chains at once. They are looked up by name or ID
+// from either the comma separated "names" query parameter or a POST body of
+// the form {"names": ["osmosis", "cosmoshub-4"]}.
+func (h *Handler) ChainBatch(res http.ResponseWriter, req *http.Request) {
+	var names []string
+	if req.Method == http.MethodPost {
+		var body struct {
+			Names []string `json:"names"`
+		}
+		if err := json.NewDecoder(io.LimitReader(req.Body, maxBatchBody)).Decode(&body); err != nil {
+			badRequest(res)
+			return
+		}
+		names = body.Names
+	} else if query := req.URL.Query().Get("names"); query != "" {
+		names = strings.Split(query, ",")
+	}
+	if len(names) == 0 || len(names) > maxBatchSize {
+		badRequest(res)
+		return
+	}
+
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	batch := types.ChainBatch{Chains: make(map[string]types.Chain, len(names)), NotFound: []string{}}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if exists, chain := h.findChain(name); exists {
+			batch.Chains[name] = chain
+		} else {
+			batch.NotFound = append(batch.NotFound, name)
+		}
+	}
+	respondWithJSON(res, batch)
+}
+
+// batchRoutes are the routes of every API version, without the version
+// prefix, that can be queried in a batch: those that answer with JSON small
+// enough to be buffered. Queries are matched against the router and refused
+// unless the template of the route they match is listed, so an encoded path
+// can't reach any other route.
+var batchRoutes = map[string]bool{
+	"/chains":                                   true,
+	"/tombstones":                               true,
+	"/chains/batch":                             true,
+	"/chain/{chain}":                            true,
+	"/chain/{chain}/endpoints/{type}":           true,
+	"/chain/{chain}/assets":                     true,
+	"/chain/{chain}/asset/{asset}":              true,
+	"/chain/{chain}/bank-metadata":              true,
+	"/chain/{chain}/explorer/tx/{hash}":         true,
+	"/chain/{chain}/explorer/account/{address}": true,
+	"/chain/{chain}/addrbook":                   true,
+	"/chain/{chain}/genesis-info":               true,
+	"/chain/{chain}/upgrades":                   true,
+	"/chain/{chain}/gas-price":                  true,
+	"/chain/{chain}/fees":                       true,
+	"/chain/{chain}/staking":                    true,
+	"/chain/{chain}/history":                    true,
+	"/chain/{chain}/chain-ids":                  true,
+	"/chain/{chain}/keplr":                      true,
+	"/chain/{chain}/wallet/{provider}":          true,
+	"/convert/address":                          true,
+	"/aliases":                                  true,
+	"/providers":                                true,
+	"/indexes":                                  true,
+	"/indexes/{name}":                           true,
+	"/gas-prices":                               true,
+	"/changes":                                  true,
+	"/stats":                                    true,
+	"/stats/registry":                           true,
+	"/stats/providers":                          true,
+	"/assets":                                   true,
+	"/assets/all":                               true,
+	"/asset/{asset}":                            true,
+	"/images":                                   true,
+	"/asset/{asset}/supply":                     true,
+	"/asset/{asset}/lineage":                    true,
+}
+
+// batchable reports whether the route matched by a query of a batch is one of
+// the batchRoutes of an API version
+func batchable(route *mux.Route) bool {
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return false
+	}
+	for _, version := range apiVersions {
+		if path := strings.TrimPrefix(template, "/"+version.prefix); path != template && batchRoutes[path] {
+			return true
+		}
+	}
+	return false
+}
+
+// Batch runs several queries of the API at once and returns their responses
+// in order, so that dashboards needing many small pieces of data can make a
+// single request. The body is of the form
+// {"queries": ["/v1/chain/osmosis", "/v1/asset/atom?include=price"]}. Each
+// query is served by the router with the headers of the batch request, so it
+// counts towards the rate limit like any other. Queries of routes that aren't
+// batchRoutes are refused with 400 Bad Request. At most maxBatchWorkers
+// queries are served at once, and queries whose response would take the batch
+// past maxBatchResponse are answered with 413 and no body.
+func Batch(router *mux.Router) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Queries []string `json:"queries"`
+		}
+		if err := json.NewDecoder(io.LimitReader(req.Body, maxBatchBody)).Decode(&body); err != nil {
+			badRequest(res)
+			return
+		}
+		if len(body.Queries) == 0 || len(body.Queries) > maxBatchSize {
+			badRequest(res)
+			return
+		}
+
+		results := make([]types.BatchResult, len(body.Queries))
+		budget := int64(maxBatchResponse)
+		workers := make(chan struct{}, maxBatchWorkers)
+		var wg sync.WaitGroup
+		for i, query := range body.Queries {
+			wg.Add(1)
+			workers <- struct{}{}
+			go func(i int, query string) {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				results[i] = batchQuery(router, req, query, &budget)
+			}(i, query)
+		}
+		wg.Wait()
+		respondWithJSON(res, results)
+	}
+}
+
+// batchQuery serves one query of a batch request, taking the size of its
+// response from the budget of the batch
+func batchQuery(router *mux.Router, batch *http.Request, query string, budget *int64) types.BatchResult {
+	result := types.BatchResult{Query: query, Status: http.StatusBadRequest}
+	if !strings.HasPrefix(query, "/") {
+		return result
+	}
+	req, err := http.NewRequestWithContext(batch.Context(), http.MethodGet, query, nil)
+	if err != nil {
+		return result
+	}
+	var match mux.RouteMatch
+	if !router.Match(req, &match) || !batchable(match.Route) {
+		return result
+	}
+	// the responses are embedded in the JSON of the batch, so they are
+	// neither protobuf nor conditional
+	req.Header = batch.Header.Clone()
+	for _, header := range []string{"Accept", "If-Modified-Since", "Content-Type", "Content-Length"} {
+		req.Header.Del(header)
+	}
+	req.RemoteAddr = batch.RemoteAddr
+	rec := &batchRecorder{header: make(http.Header), budget: budget}
+	router.ServeHTTP(rec, req)
+
+	result.Status = rec.status
+	switch response := rec.body.Bytes(); {
+	case rec.over:
+		result.Status = http.StatusRequestEntityTooLarge
+	case len(response) == 0:
+	case strings.HasPrefix(rec.header.Get("Content-Type"), "application/json") && json.Valid(response):
+		result.Body = response
+	default:
+		result.Body, _ = json.Marshal(string(response))
+	}
+	return result
+}
+
+// batchRecorder records the response to a query of a batch. Once the body
+// would exceed what is left of the budget of the batch it is dropped and the
+// rest of the response is discarded.
+type batchRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	budget *int64
+	over   bool
+}
+
+func (r *batchRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *batchRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *batchRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	if r.over {
+		return len(b), nil
+	}
+	if atomic.AddInt64(r.budget, -int64(len(b))) < 0 {
+		atomic.AddInt64(r.budget, int64(len(b)+r.body.Len()))
+		r.over = true
+		r.body = bytes.Buffer{}
+		return len(b), nil
+	}
+	return r.body.Write(b)
+}
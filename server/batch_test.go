@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+func TestBatch(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	h := newTestHandler(rs.URL)
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	router := mux.NewRouter()
+	registerRoutes(router, h, options{})
+
+	queries := []string{
+		"/v1/chain/osmosis",
+		"/v2/chain/cosmoshub",
+		"/v1/chain/unknown",
+		"/v1/chain/osmosis/genesis",
+		"/raw/osmosis/chain.json",
+		"/%61dmin/export",
+		"/batch",
+		"v1/chains",
+	}
+	body, _ := json.Marshal(map[string][]string{"queries": queries})
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(string(body))))
+	if res.Code != http.StatusOK {
+		t.Fatalf("batch answered %d", res.Code)
+	}
+	var results []types.BatchResult
+	if err := json.Unmarshal(res.Body.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	want := []int{200, 200, 404, 400, 400, 400, 400, 400}
+	for i, result := range results {
+		if result.Query != queries[i] || result.Status != want[i] {
+			t.Errorf("%s answered %d, want %d", result.Query, result.Status, want[i])
+		}
+	}
+	var chain types.Chain
+	if err := json.Unmarshal(results[0].Body, &chain); err != nil || chain.ChainID != "osmosis-1" {
+		t.Errorf("chain %q in the body of the first query, error %v", chain.ChainID, err)
+	}
+}
+
+func TestBatchQueryBudget(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	h := newTestHandler(rs.URL)
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	router := mux.NewRouter()
+	registerRoutes(router, h, options{})
+	batch := httptest.NewRequest(http.MethodPost, "/batch", nil)
+
+	budget := int64(64)
+	result := batchQuery(router, batch, "/v1/chain/osmosis", &budget)
+	if result.Status != http.StatusRequestEntityTooLarge || result.Body != nil {
+		t.Errorf("response over the budget answered %d with %d bytes", result.Status, len(result.Body))
+	}
+	if budget != 64 {
+		t.Errorf("budget is %d after a dropped response, want 64", budget)
+	}
+	result = batchQuery(router, batch, "/v1/chains", &budget)
+	if result.Status != http.StatusOK || budget != 64-int64(len(result.Body)) {
+		t.Errorf("response within the budget answered %d, budget left %d", result.Status, budget)
+	}
+}
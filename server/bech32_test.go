@@ -0,0 +1,57 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+// the valid and invalid strings are the test vectors of BIP 173
+func TestBech32Decode(t *testing.T) {
+	for _, s := range []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		"?1ezyfcl",
+	} {
+		hrp, data, err := bech32Decode(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+		// encoding is lower case
+		if got, want := bech32Encode(hrp, data), strings.ToLower(s); got != want {
+			t.Errorf("%s: encoded back as %s", s, got)
+		}
+	}
+
+	for _, s := range []string{
+		"\x201nwldj5",  // hrp character out of range
+		"\x7f1axkwrx",  // hrp character out of range
+		"pzry9x0s0muk", // no separator
+		"1pzry9x0s0muk",
+		"x1b4n0q5v", // invalid data character
+		"li1dgmt3",  // checksum too short
+		"A1G7SGD8",  // checksum calculated with an upper case hrp
+		"10a06t8",
+		"1qzzfhee",
+		"A12uEL5L", // mixed case
+	} {
+		if _, _, err := bech32Decode(s); err == nil {
+			t.Errorf("%q decoded but is invalid", s)
+		}
+	}
+}
+
+func TestBech32Reencode(t *testing.T) {
+	_, data, err := bech32Decode("cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	osmo := bech32Encode("osmo", data)
+	hrp, again, err := bech32Decode(osmo)
+	if err != nil || hrp != "osmo" || string(again) != string(data) {
+		t.Errorf("re-encoded address %s doesn't decode to the same data: %v", osmo, err)
+	}
+}
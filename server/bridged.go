@@ -0,0 +1,38 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// bridgedAssets returns the assets of other chains that were transferred over
+// IBC directly from the chain's asset, ordered by chain name. Assets whose
+// source chain isn't recorded match on the denom alone. The caller must hold
+// the read lock.
+func (h *Handler) bridgedAssets(chain string, asset types.AssetElement) []types.ChainAssetElement {
+	names := make([]string, 0, len(h.assetList))
+	for name := range h.assetList {
+		if name != chain {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	bridged := make([]types.ChainAssetElement, 0)
+	for _, name := range names {
+		assetList := h.assetList[name]
+		for _, variant := range assetList.Assets {
+			ibc := variant.Ibc
+			if ibc == nil || ibc.SourceDenom != asset.Base || (ibc.SourceChain != "" && ibc.SourceChain != chain) {
+				continue
+			}
+			bridged = append(bridged, types.ChainAssetElement{
+				Chain:        name,
+				ChainID:      assetList.ChainID,
+				AssetElement: variant,
+			})
+		}
+	}
+	return bridged
+}
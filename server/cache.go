@@ -0,0 +1,122 @@
+package server
+
+import (
+	"container/list"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// cacheMaxAge is how long clients and CDNs may cache responses of the registry
+// without revalidating them
+const cacheMaxAge = 5 * time.Minute
+
+// maxCachedBytes bounds the size of the encoded responses kept by the cache.
+// Lookups that resolve case insensitively or by earlier chain IDs reach the
+// same response through many paths, so the least recently used responses are
+// evicted rather than letting clients grow the cache until the next update.
+const maxCachedBytes = 64 << 20
+
+// responseCache holds encoded responses keyed by request. It is reset whenever
+// the registry is updated so hot endpoints only need to be encoded once per
+// update.
+type responseCache struct {
+	mtx     sync.Mutex
+	entries map[string]*list.Element
+	// order holds the entries, most recently used first
+	order *list.List
+	size  int
+}
+
+type cachedResponse struct {
+	contentType string
+	body        []byte
+}
+
+// cacheEntry is an element of the order of the cache
+type cacheEntry struct {
+	key      string
+	response cachedResponse
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]*list.Element), order: list.New()}
+}
+
+func (c *responseCache) get(key string) (cachedResponse, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return cachedResponse{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).response, true
+}
+
+// set caches the response, evicting the least recently used responses once
+// the cache holds more than maxCachedBytes. Responses larger than that aren't
+// cached.
+func (c *responseCache) set(key string, response cachedResponse) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if len(response.body) > maxCachedBytes {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, response: response})
+	c.size += len(response.body)
+	for c.size > maxCachedBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove drops the entry. The caller must hold the lock.
+func (c *responseCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.response.body)
+}
+
+// reset drops all cached responses
+func (c *responseCache) reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.size = 0
+}
+
+// notModified sets the headers that let clients and CDNs cache a response of
+// the registry, and answers with 304 Not Modified if the registry hasn't been
+// updated since the If-Modified-Since header. The caller must hold the read
+// lock.
+func (h *Handler) notModified(w http.ResponseWriter, req *http.Request) bool {
+	w.Header().Set("Last-Modified", h.lastUpdated.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheMaxAge.Seconds())))
+	// protobuf and JSON responses share a url
+	w.Header().Set("Vary", "Accept")
+
+	since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil || h.lastUpdated.Truncate(time.Second).After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// HeadAsGet answers HEAD requests as though they were GET requests. The body
+// is discarded by the server as the original request is a HEAD request.
+func HeadAsGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodHead {
+			get := req.Clone(req.Context())
+			get.Method = http.MethodGet
+			req = get
+		}
+		next.ServeHTTP(res, req)
+	})
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResponseCacheBound(t *testing.T) {
+	c := newResponseCache()
+	body := make([]byte, maxCachedBytes/4)
+	c.set("/v1/chain/osmosis", cachedResponse{body: body})
+	for i := 0; i < 8; i++ {
+		// the hot entry is used between the other requests
+		if _, ok := c.get("/v1/chain/osmosis"); !ok {
+			t.Fatalf("recently used response was evicted after %d others", i)
+		}
+		c.set("/v1/chain/osmosis-"+strings.Repeat("0", i+1), cachedResponse{body: body})
+	}
+	if c.size > maxCachedBytes {
+		t.Errorf("cache holds %d bytes, want at most %d", c.size, maxCachedBytes)
+	}
+	if len(c.entries) != 4 || c.order.Len() != 4 {
+		t.Errorf("cache holds %d entries, want 4", len(c.entries))
+	}
+
+	c.set("/v1/huge", cachedResponse{body: make([]byte, maxCachedBytes+1)})
+	if _, ok := c.get("/v1/huge"); ok {
+		t.Error("response larger than the cache was cached")
+	}
+	c.reset()
+	if c.size != 0 || c.order.Len() != 0 {
+		t.Errorf("reset left %d bytes in %d entries", c.size, c.order.Len())
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// SetPreviousChainIDs sets chain ids that chains were known by before their
+// current one, keyed by the chain name, e.g. {"cosmoshub": ["cosmoshub-3"]}.
+// They take effect from the next pull.
+func (h *Handler) SetPreviousChainIDs(ids map[string][]string) {
+	h.prevIDs = ids
+}
+
+// indexPreviousChainIDs adds the configured previous chain ids and those of
+// the chain's earlier versions to the lookup index. A previous chain id never
+// shadows the name, id or alias of another chain. The caller must hold the
+// write lock.
+func (h *Handler) indexPreviousChainIDs() {
+	h.chainByPrevID = make(map[string]string)
+	h.previousIDs = make(map[string][]string)
+	for _, name := range h.chains {
+		current := h.chainList[name].ChainID
+		ids := append([]string{}, h.prevIDs[name]...)
+		for _, version := range h.history[name].Chain {
+			ids = append(ids, version.Chain.ChainID)
+		}
+		for _, id := range ids {
+			key := strings.ToLower(id)
+			if _, ok := h.chainByKey[key]; ok || key == "" || id == current {
+				continue
+			}
+			if _, ok := h.chainByPrevID[key]; ok {
+				continue
+			}
+			h.chainByPrevID[key] = name
+			h.previousIDs[name] = append(h.previousIDs[name], id)
+		}
+		sort.Strings(h.previousIDs[name])
+	}
+}
+
+// previousChainID returns the chain that id was a previous chain id of, either
+// one that was configured or seen in an earlier version of the chain, or an
+// earlier revision of the chain's current id, e.g. cosmoshub-3 for
+// cosmoshub-4. The caller must hold the read lock.
+func (h *Handler) previousChainID(id string) (string, bool) {
+	key := strings.ToLower(id)
+	if _, ok := h.chainByKey[key]; ok {
+		return "", false
+	}
+	if name, ok := h.chainByPrevID[key]; ok {
+		return name, true
+	}
+	name, ok := h.chainByNet[strings.ToLower(chainNetwork(id))]
+	if !ok {
+		return "", false
+	}
+	revision, ok := chainRevision(id)
+	if !ok {
+		return "", false
+	}
+	current, ok := chainRevision(h.chainList[name].ChainID)
+	return name, ok && revision < current
+}
+
+// chainRevision returns the revision number of a chain id, e.g. 4 for
+// cosmoshub-4
+func chainRevision(chainID string) (uint64, bool) {
+	i := strings.LastIndex(chainID, "-")
+	if i <= 0 {
+		return 0, false
+	}
+	revision, err := strconv.ParseUint(chainID[i+1:], 10, 64)
+	return revision, err == nil
+}
+
+// DeprecatedChainID adds a Warning header to the responses of requests that
+// look a chain up by one of its previous chain ids, pointing to the current
+// one
+func (h *Handler) DeprecatedChainID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if id, ok := mux.Vars(req)["chain"]; ok {
+			h.mtx.RLock()
+			name, previous := h.previousChainID(id)
+			current := h.chainList[name].ChainID
+			h.mtx.RUnlock()
+			if previous {
+				message := fmt.Sprintf("%s is a previous chain id of %s, use %s instead", id, name, current)
+				res.Header().Add("Warning", fmt.Sprintf("299 skychart %q", message))
+			}
+		}
+		next.ServeHTTP(res, req)
+	})
+}
+
+// ChainIDs returns the current and previous chain ids of a chain
+func (h *Handler) ChainIDs(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	name, exists := h.resolveChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	chain, ok := h.chainList[name]
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	previous := h.previousIDs[name]
+	if previous == nil {
+		previous = []string{}
+	}
+	h.respond(res, req, types.ChainIDs{ChainID: chain.ChainID, Previous: previous})
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+func TestChainRevisions(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	rs.SetChain("osmosis", types.Chain{ChainName: "osmosis", ChainID: "osmosis-2", Bech32Prefix: "osmo"})
+	h := newTestHandler(rs.URL)
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	router := mux.NewRouter()
+	useRegistryMiddleware(router, h)
+	registerRoutes(router, h, options{})
+
+	for id, want := range map[string]int{"osmosis-2": 200, "osmosis-1": 200, "osmosis-3": 404, "osmosis-x": 404} {
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/v1/chain/"+id, nil))
+		if res.Code != want {
+			t.Errorf("%s answered %d, want %d", id, res.Code, want)
+		}
+		if warned := res.Header().Get("Warning") != ""; warned != (id == "osmosis-1") {
+			t.Errorf("%s warned %v", id, warned)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// maxChanges bounds the number of change records kept by the handler
+	maxChanges = 10000
+
+	chainRecord = "chain"
+	assetRecord = "asset"
+
+	added    = "added"
+	removed  = "removed"
+	modified = "modified"
+)
+
+// change records that a chain or asset was added, removed or modified by a
+// pull. Assets are identified by their display name.
+type change struct {
+	time   time.Time
+	kind   string
+	name   string
+	action string
+}
+
+// recordChanges compares the chain and asset lists from before an update with
+// the handler's current lists and records the differences. It returns the
+// number of changes recorded. The caller must hold the write lock.
+func (h *Handler) recordChanges(chains map[string]types.Chain, assetLists map[string]types.AssetList, now time.Time) int {
+	count := len(h.changes)
+	for name, chain := range h.chainList {
+		prev, ok := chains[name]
+		switch {
+		case !ok:
+			h.changes = append(h.changes, change{now, chainRecord, name, added})
+		case !reflect.DeepEqual(prev, chain):
+			h.changes = append(h.changes, change{now, chainRecord, name, modified})
+		}
+	}
+	for name := range chains {
+		if _, ok := h.chainList[name]; !ok {
+			h.changes = append(h.changes, change{now, chainRecord, name, removed})
+		}
+	}
+
+	before, after := assetsByDisplay(assetLists), assetsByDisplay(h.assetList)
+	for display, asset := range after {
+		prev, ok := before[display]
+		switch {
+		case !ok:
+			h.changes = append(h.changes, change{now, assetRecord, display, added})
+		case !reflect.DeepEqual(prev, asset):
+			h.changes = append(h.changes, change{now, assetRecord, display, modified})
+		}
+	}
+	for display := range before {
+		if _, ok := after[display]; !ok {
+			h.changes = append(h.changes, change{now, assetRecord, display, removed})
+		}
+	}
+
+	count = len(h.changes) - count
+
+	if len(h.changes) > maxChanges {
+		h.changesFrom = h.changes[len(h.changes)-maxChanges-1].time
+		h.changes = h.changes[len(h.changes)-maxChanges:]
+	}
+	return count
+}
+
+func assetsByDisplay(assetLists map[string]types.AssetList) map[string]types.AssetElement {
+	assets := make(map[string]types.AssetElement)
+	for _, assetList := range assetLists {
+		for _, asset := range assetList.Assets {
+			assets[asset.Display] = asset
+		}
+	}
+	return assets
+}
+
+// changesSince aggregates the change records after since into the net change
+// of each chain and asset. The caller must hold the read lock.
+func (h *Handler) changesSince(since time.Time) types.Changes {
+	type history struct{ first, last string }
+	histories := map[string]map[string]*history{
+		chainRecord: make(map[string]*history),
+		assetRecord: make(map[string]*history),
+	}
+	for _, c := range h.changes {
+		if !c.time.After(since) {
+			continue
+		}
+		if hist, ok := histories[c.kind][c.name]; ok {
+			hist.last = c.action
+		} else {
+			histories[c.kind][c.name] = &history{first: c.action, last: c.action}
+		}
+	}
+
+	changeSet := func(kind string) types.ChangeSet {
+		set := types.ChangeSet{Added: []string{}, Removed: []string{}, Modified: []string{}}
+		for name, hist := range histories[kind] {
+			switch {
+			case hist.first == added && hist.last == removed:
+				// a record that came and went is no change at all
+			case hist.first == added:
+				set.Added = append(set.Added, name)
+			case hist.last == removed:
+				set.Removed = append(set.Removed, name)
+			default:
+				set.Modified = append(set.Modified, name)
+			}
+		}
+		sort.Strings(set.Added)
+		sort.Strings(set.Removed)
+		sort.Strings(set.Modified)
+		return set
+	}
+
+	return types.Changes{
+		Since:    since,
+		Complete: !since.Before(h.changesFrom),
+		Chains:   changeSet(chainRecord),
+		Assets:   changeSet(assetRecord),
+	}
+}
+
+// Changes returns the chains and assets that were added, removed or modified
+// since the time given by the "since" query parameter, either as RFC3339 or a
+// unix timestamp
+func (h *Handler) Changes(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	since, err := parseTime(req.URL.Query().Get("since"))
+	if err != nil {
+		badRequest(res)
+		return
+	}
+	respondWithJSON(res, h.changesSince(since))
+}
+
+func parseTime(value string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
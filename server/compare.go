@@ -0,0 +1,246 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// registryState is the registry at some point in its history
+type registryState struct {
+	info       types.RegistryState
+	chains     map[string]types.Chain
+	assetLists map[string]types.AssetList
+}
+
+// Compare returns the difference between the registry at ?base= and ?head=,
+// each either the served commit, a timestamp as accepted by /changes, or the
+// name or ref of a preview. Head defaults to the registry currently served.
+// Timestamps are looked up in the history of each chain. Other refs are
+// compared by creating a preview of them first, so that requests never pull.
+func (h *Handler) Compare(res http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	if query.Get("base") == "" {
+		badRequest(res)
+		return
+	}
+	states := make([]registryState, 2)
+	for i, param := range []string{"base", "head"} {
+		state, status := h.registryAt(query.Get(param))
+		switch status {
+		case http.StatusOK:
+		case http.StatusBadRequest:
+			badRequest(res)
+			return
+		case http.StatusServiceUnavailable:
+			res.Header().Set("Retry-After", "10")
+			http.Error(res, "the preview of "+query.Get(param)+" is loading", http.StatusServiceUnavailable)
+			return
+		default:
+			badGateway(res)
+			return
+		}
+		states[i] = state
+	}
+	respondWithJSON(res, compareStates(states[0], states[1]))
+}
+
+// registryAt returns the state of the registry at the ref, along with the
+// status to respond with if it can't be found
+func (h *Handler) registryAt(ref string) (registryState, int) {
+	h.mtx.RLock()
+	commit := h.commit
+	if ref == "" || (len(ref) >= 7 && strings.HasPrefix(commit, ref)) {
+		state := registryState{
+			info:       types.RegistryState{Ref: ref, Commit: commit, Complete: true},
+			chains:     h.chainList,
+			assetLists: h.assetList,
+		}
+		h.mtx.RUnlock()
+		return state, http.StatusOK
+	}
+	if t, err := parseTime(ref); err == nil {
+		state := h.registryAtTime(t)
+		state.info.Ref = ref
+		h.mtx.RUnlock()
+		return state, http.StatusOK
+	}
+	h.mtx.RUnlock()
+
+	// other refs are only compared once a preview has loaded them
+	h.previews.mtx.RLock()
+	var p *preview
+	for name, candidate := range h.previews.byName {
+		if name == ref || candidate.ref == ref {
+			p = candidate
+		}
+	}
+	var loaded bool
+	var state string
+	if p != nil {
+		loaded, state = !p.loaded.IsZero(), p.state
+	}
+	h.previews.mtx.RUnlock()
+	switch {
+	case p == nil:
+		return registryState{}, http.StatusBadRequest
+	case !loaded && state == previewFailed:
+		return registryState{}, http.StatusBadGateway
+	case !loaded:
+		return registryState{}, http.StatusServiceUnavailable
+	}
+	preview := p.handler
+	preview.mtx.RLock()
+	defer preview.mtx.RUnlock()
+	return registryState{
+		info:       types.RegistryState{Ref: ref, Commit: preview.commit, Complete: true},
+		chains:     preview.chainList,
+		assetLists: preview.assetList,
+	}, http.StatusOK
+}
+
+// registryAtTime rebuilds the registry as it was served at the time from the
+// history of each chain and the tombstones of removed chains. The caller must
+// hold the read lock.
+func (h *Handler) registryAtTime(t time.Time) registryState {
+	state := registryState{
+		chains:     make(map[string]types.Chain),
+		assetLists: make(map[string]types.AssetList),
+	}
+	// the history reaches back to the first pull, unless the oldest versions
+	// of a chain were dropped
+	var start, dropped time.Time
+	for name, history := range h.history {
+		var firsts []time.Time
+		if len(history.Chain) > 0 {
+			firsts = append(firsts, history.Chain[0].Timestamp)
+			if len(history.Chain) == maxVersions && history.Chain[0].Timestamp.After(dropped) {
+				dropped = history.Chain[0].Timestamp
+			}
+		}
+		if len(history.AssetList) > 0 {
+			firsts = append(firsts, history.AssetList[0].Timestamp)
+			if len(history.AssetList) == maxVersions && history.AssetList[0].Timestamp.After(dropped) {
+				dropped = history.AssetList[0].Timestamp
+			}
+		}
+		for _, first := range firsts {
+			if start.IsZero() || first.Before(start) {
+				start = first
+			}
+		}
+
+		if tombstone, ok := h.tombstones[name]; ok && !tombstone.Removed.After(t) {
+			continue
+		}
+		for i := len(history.Chain) - 1; i >= 0; i-- {
+			if !history.Chain[i].Timestamp.After(t) {
+				state.chains[name] = history.Chain[i].Chain
+				break
+			}
+		}
+		for i := len(history.AssetList) - 1; i >= 0; i-- {
+			if !history.AssetList[i].Timestamp.After(t) {
+				state.assetLists[name] = history.AssetList[i].AssetList
+				break
+			}
+		}
+	}
+	state.info.Complete = !start.IsZero() && !t.Before(start) && !t.Before(dropped)
+	return state
+}
+
+// compareStates returns the difference between the base and head states
+func compareStates(base, head registryState) types.Comparison {
+	comparison := types.Comparison{
+		Base:      base.info,
+		Head:      head.info,
+		Chains:    compareRecords(recordSet(base.chains), recordSet(head.chains)),
+		Assets:    compareRecords(assetSet(base.assetLists), assetSet(head.assetLists)),
+		Endpoints: make(map[string]map[string]types.EndpointChanges),
+	}
+	for name, headChain := range head.chains {
+		baseChain, ok := base.chains[name]
+		if !ok {
+			continue
+		}
+		before, after := chainEndpoints(baseChain), chainEndpoints(headChain)
+		for _, kind := range endpointKinds {
+			added := missingAddresses(after[kind], before[kind])
+			removed := missingAddresses(before[kind], after[kind])
+			if len(added) == 0 && len(removed) == 0 {
+				continue
+			}
+			if comparison.Endpoints[name] == nil {
+				comparison.Endpoints[name] = make(map[string]types.EndpointChanges)
+			}
+			comparison.Endpoints[name][kind] = types.EndpointChanges{Added: added, Removed: removed}
+		}
+	}
+	return comparison
+}
+
+// recordSet returns the chains as a set of comparable records
+func recordSet(chains map[string]types.Chain) map[string]interface{} {
+	records := make(map[string]interface{}, len(chains))
+	for name, chain := range chains {
+		records[name] = chain
+	}
+	return records
+}
+
+// assetSet returns the assets of every chain keyed as {chain}/{base denom}
+func assetSet(assetLists map[string]types.AssetList) map[string]interface{} {
+	records := make(map[string]interface{})
+	for name, assetList := range assetLists {
+		for _, asset := range assetList.Assets {
+			records[name+"/"+asset.Base] = asset
+		}
+	}
+	return records
+}
+
+// compareRecords returns the records added, removed and modified from base to
+// head
+func compareRecords(base, head map[string]interface{}) types.ChangeSet {
+	set := types.ChangeSet{Added: []string{}, Removed: []string{}, Modified: []string{}}
+	for name, record := range head {
+		prev, ok := base[name]
+		switch {
+		case !ok:
+			set.Added = append(set.Added, name)
+		case !reflect.DeepEqual(prev, record):
+			set.Modified = append(set.Modified, name)
+		}
+	}
+	for name := range base {
+		if _, ok := head[name]; !ok {
+			set.Removed = append(set.Removed, name)
+		}
+	}
+	sort.Strings(set.Added)
+	sort.Strings(set.Removed)
+	sort.Strings(set.Modified)
+	return set
+}
+
+// missingAddresses returns the sorted addresses of the endpoints that aren't
+// in others
+func missingAddresses(endpoints, others []endpoint) []string {
+	listed := make(map[string]bool, len(others))
+	for _, other := range others {
+		listed[other.address] = true
+	}
+	missing := []string{}
+	for _, endpoint := range endpoints {
+		if !listed[endpoint.address] {
+			missing = append(missing, endpoint.address)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
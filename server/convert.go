@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// ConvertAddress re-encodes the bech32 address given by ?address= with the
+// prefix of the chain given by ?to=. Validator and public key addresses keep
+// their kind, so a cosmosvaloper address becomes an osmovaloper address.
+func (h *Handler) ConvertAddress(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	query := req.URL.Query()
+	address, to := query.Get("address"), query.Get("to")
+	if address == "" || to == "" {
+		badRequest(res)
+		return
+	}
+	exists, chain := h.findChain(to)
+	if !exists || chain.Bech32Prefix == "" {
+		resourceNotFound(res)
+		return
+	}
+	hrp, data, err := bech32Decode(address)
+	if err != nil || len(data) == 0 {
+		badRequest(res)
+		return
+	}
+	prefix := chain.Bech32Prefix + h.bech32Suffix(hrp)
+	respondWithJSON(res, types.AddressConversion{
+		Address: bech32Encode(prefix, data),
+		Chain:   chain.ChainName,
+		Prefix:  prefix,
+	})
+}
+
+// bech32Suffix returns the suffix of the human readable part of an address
+// that marks its kind, e.g. valoper, if the rest of it is the prefix of a
+// chain in the registry. The caller must hold the read lock.
+func (h *Handler) bech32Suffix(hrp string) string {
+	for _, suffix := range bech32Suffixes {
+		if !strings.HasSuffix(hrp, suffix) {
+			continue
+		}
+		prefix := strings.TrimSuffix(hrp, suffix)
+		for _, chain := range h.chainList {
+			if chain.Bech32Prefix == prefix {
+				return suffix
+			}
+		}
+	}
+	return ""
+}
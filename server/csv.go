@@ -0,0 +1,47 @@
+package server
+
+import (
+	"bytes"
+	"encoding/csv"
+	"sort"
+	"strconv"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// assetsCSV flattens the asset lists, keyed by chain name, into a CSV table
+// with one row per asset. Chains are sorted by name.
+func assetsCSV(assetLists map[string]types.AssetList) string {
+	chains := make([]string, 0, len(assetLists))
+	for chain := range assetLists {
+		chains = append(chains, chain)
+	}
+	sort.Strings(chains)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"chain", "symbol", "base", "display", "exponent", "coingecko_id"})
+	for _, chain := range chains {
+		for _, asset := range assetLists[chain].Assets {
+			currency := keplrCurrency(asset)
+			coingeckoID := ""
+			if asset.CoingeckoID != nil {
+				coingeckoID = *asset.CoingeckoID
+			}
+			symbol := ""
+			if asset.Symbol != nil {
+				symbol = *asset.Symbol
+			}
+			_ = w.Write([]string{
+				chain,
+				symbol,
+				asset.Base,
+				asset.Display,
+				strconv.FormatInt(currency.CoinDecimals, 10),
+				coingeckoID,
+			})
+		}
+	}
+	w.Flush()
+	return buf.String()
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirSource pulls the registry from a directory on disk, such as a checkout
+// of a monorepo that vendors the registry or a directory synced from an
+// internal service. The directory mirrors the layout of the registry repo.
+type DirSource struct {
+	Root string
+}
+
+var _ Source = DirSource{}
+
+// errChanged stops walking the registry once a change is found
+var errChanged = errors.New("changed")
+
+// Changed returns true if any file of the registry was modified since the
+// given time. As with S3Source, deleted files aren't detected until another
+// file changes.
+func (s DirSource) Changed(ctx context.Context, since time.Time) (bool, error) {
+	err := filepath.WalkDir(s.Root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(since) {
+			return errChanged
+		}
+		return nil
+	})
+	if errors.Is(err, errChanged) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (s DirSource) Chains(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.Root)
+	if err != nil {
+		return nil, err
+	}
+	chains := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && isChainDir(entry.Name()) {
+			chains = append(chains, entry.Name())
+		}
+	}
+	return chains, nil
+}
+
+func (s DirSource) OpenFile(ctx context.Context, chain, file string) (io.ReadCloser, bool, error) {
+	f, err := os.Open(filepath.Join(s.Root, chain, file))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return f, true, nil
+}
+
+func (s DirSource) File(ctx context.Context, chain, file string) ([]byte, bool, error) {
+	bz, err := os.ReadFile(filepath.Join(s.Root, chain, file))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return bz, true, nil
+}
+
+// RootFile returns a file at the root of the directory
+func (s DirSource) RootFile(ctx context.Context, _, file string) ([]byte, bool, error) {
+	return s.File(ctx, "", file)
+}
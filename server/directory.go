@@ -0,0 +1,122 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// The directory routes mirror the URL scheme and response envelopes of
+// chains.cosmos.directory so that existing clients of that service can use
+// skychart by swapping the base URL.
+
+type directoryRepository struct {
+	URL       string `json:"url"`
+	Branch    string `json:"branch"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type directoryChain struct {
+	Name         string             `json:"name"`
+	Path         string             `json:"path"`
+	ChainName    string             `json:"chain_name"`
+	NetworkType  *types.NetworkType `json:"network_type,omitempty"`
+	PrettyName   *string            `json:"pretty_name,omitempty"`
+	ChainID      string             `json:"chain_id"`
+	Status       *types.Status      `json:"status,omitempty"`
+	Bech32Prefix string             `json:"bech32_prefix"`
+	Slip44       *float64           `json:"slip44,omitempty"`
+	Symbol       *string            `json:"symbol,omitempty"`
+	Display      string             `json:"display,omitempty"`
+	Denom        string             `json:"denom,omitempty"`
+	Decimals     int64              `json:"decimals,omitempty"`
+	CoingeckoID  *string            `json:"coingecko_id,omitempty"`
+	Image        *string            `json:"image,omitempty"`
+}
+
+// DirectoryRoutes registers the cosmos.directory compatible routes on the router
+func (h *Handler) DirectoryRoutes(router *mux.Router) {
+	router.HandleFunc("/", h.DirectoryChains).Methods("GET")
+	router.HandleFunc("/{chain}", h.DirectoryChain).Methods("GET")
+	router.HandleFunc("/{chain}/chain", h.Chain).Methods("GET")
+	router.HandleFunc("/{chain}/assetlist", h.ChainAsset).Methods("GET")
+}
+
+func (h *Handler) DirectoryChains(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	chains := make([]directoryChain, 0, len(h.chainList))
+	for name := range h.chainList {
+		chains = append(chains, h.directoryChain(name))
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].Name < chains[j].Name })
+
+	h.respond(res, req, struct {
+		Repository directoryRepository `json:"repository"`
+		Chains     []directoryChain    `json:"chains"`
+	}{h.directoryRepository(), chains})
+}
+
+func (h *Handler) DirectoryChain(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	name, exists := h.resolveChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+
+	respondWithJSON(res, struct {
+		Repository directoryRepository `json:"repository"`
+		Chain      directoryChain      `json:"chain"`
+	}{h.directoryRepository(), h.directoryChain(name)})
+}
+
+func (h *Handler) directoryRepository() directoryRepository {
+	return directoryRepository{
+		URL:       fmt.Sprintf("https://github.com/%s", h.registryUrl),
+		Branch:    h.branch,
+		Timestamp: h.lastUpdated.Unix(),
+	}
+}
+
+// directoryChain summarises a chain in the format of cosmos.directory. The
+// native asset is taken to be the first asset in the chain's asset list.
+func (h *Handler) directoryChain(name string) directoryChain {
+	chain := h.chainList[name]
+	summary := directoryChain{
+		Name:         name,
+		Path:         name,
+		ChainName:    chain.ChainName,
+		NetworkType:  chain.NetworkType,
+		PrettyName:   chain.PrettyName,
+		ChainID:      chain.ChainID,
+		Status:       chain.Status,
+		Bech32Prefix: chain.Bech32Prefix,
+		Slip44:       chain.Slip44,
+	}
+
+	assetList := h.assetList[name]
+	if len(assetList.Assets) > 0 {
+		currency := keplrCurrency(assetList.Assets[0])
+		summary.Symbol = assetList.Assets[0].Symbol
+		summary.Display = assetList.Assets[0].Display
+		summary.Denom = currency.CoinMinimalDenom
+		summary.Decimals = currency.CoinDecimals
+		summary.CoingeckoID = currency.CoinGeckoID
+		summary.Image = currency.CoinImageURL
+	}
+	return summary
+}
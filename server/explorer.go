@@ -0,0 +1,82 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// placeholders substituted in the tx_page and account_page templates of the
+// registry's explorers
+const (
+	txHashPlaceholder  = "${txHash}"
+	accountPlaceholder = "${accountAddress}"
+)
+
+// ExplorerTx returns the URL of a transaction on the chain's preferred
+// explorer
+func (h *Handler) ExplorerTx(res http.ResponseWriter, req *http.Request) {
+	h.explorerLink(res, req, "hash", txHashPlaceholder, func(explorer types.ExplorerElement) *string {
+		return explorer.TxPage
+	})
+}
+
+// ExplorerAccount returns the URL of an account on the chain's preferred
+// explorer
+func (h *Handler) ExplorerAccount(res http.ResponseWriter, req *http.Request) {
+	h.explorerLink(res, req, "address", accountPlaceholder, func(explorer types.ExplorerElement) *string {
+		return explorer.AccountPage
+	})
+}
+
+// explorerLink fills the page template of the first explorer that has one
+// with the value of the path variable. The "kind" query parameter picks a
+// specific explorer, e.g. mintscan. The client is redirected to the URL if
+// the "redirect" query parameter is true.
+func (h *Handler) explorerLink(res http.ResponseWriter, req *http.Request, variable, placeholder string, page func(types.ExplorerElement) *string) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	value, ok := vars[variable]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	exists, chain := h.findChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+
+	kind := req.URL.Query().Get("kind")
+	for _, explorer := range chain.Explorers {
+		template := page(explorer)
+		if template == nil || !strings.Contains(*template, placeholder) {
+			continue
+		}
+		if kind != "" && (explorer.Kind == nil || !strings.EqualFold(*explorer.Kind, kind)) {
+			continue
+		}
+		link := types.ExplorerLink{URL: strings.ReplaceAll(*template, placeholder, url.PathEscape(value))}
+		if explorer.Kind != nil {
+			link.Kind = *explorer.Kind
+		}
+		if req.URL.Query().Get("redirect") == "true" {
+			http.Redirect(res, req, link.URL, http.StatusFound)
+			return
+		}
+		respondWithJSON(res, link)
+		return
+	}
+	resourceNotFound(res)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// FieldProjection is middleware that trims JSON responses to the fields listed
+// in ?fields=, e.g. /v1/chain/osmosis?fields=chain_id,apis.rpc. Nested fields
+// are separated by dots and apply to every element of an array. Fields that
+// don't exist are left out.
+func FieldProjection(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		fields := req.URL.Query().Get("fields")
+		if fields == "" {
+			next.ServeHTTP(res, req)
+			return
+		}
+		paths := make([][]string, 0)
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				paths = append(paths, strings.Split(field, "."))
+			}
+		}
+		rewriteJSON(res, req, next, func(body []byte) ([]byte, error) {
+			// numbers are kept as they are rather than rounded to a float64
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			var value interface{}
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+			return json.Marshal(project(value, paths))
+		})
+	})
+}
+
+// project keeps the paths of the value. Arrays are projected element by
+// element and values that aren't objects are kept whole.
+func project(value interface{}, paths [][]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		nested := make(map[string][][]string)
+		whole := make(map[string]bool)
+		for _, path := range paths {
+			if len(path) == 1 {
+				whole[path[0]] = true
+			} else {
+				nested[path[0]] = append(nested[path[0]], path[1:])
+			}
+		}
+		projected := make(map[string]interface{})
+		for key, field := range v {
+			switch {
+			case whole[key]:
+				projected[key] = field
+			case len(nested[key]) > 0:
+				projected[key] = project(field, nested[key])
+			}
+		}
+		return projected
+	case []interface{}:
+		projected := make([]interface{}, len(v))
+		for i, element := range v {
+			projected[i] = project(element, paths)
+		}
+		return projected
+	default:
+		return value
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFieldProjection(t *testing.T) {
+	body := `{"chain_name":"osmosis","chain_id":"osmosis-1","slip44":118000000000000000001,
+		"apis":{"rpc":[{"address":"https://rpc.osmosis.zone","provider":"Osmosis"}],"rest":[{"address":"https://lcd.osmosis.zone"}]},
+		"explorers":[{"kind":"mintscan","url":"https://mintscan.io"},{"kind":"ping"}]}`
+	next := http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-Type", "application/json")
+		_, _ = res.Write([]byte(body))
+	})
+
+	tests := []struct {
+		fields string
+		want   string
+	}{
+		{"", body},
+		{"chain_id", `{"chain_id":"osmosis-1"}`},
+		// numbers keep their precision
+		{"slip44", `{"slip44":118000000000000000001}`},
+		{"chain_id,apis.rpc.address", `{"apis":{"rpc":[{"address":"https://rpc.osmosis.zone"}]},"chain_id":"osmosis-1"}`},
+		{"explorers.url", `{"explorers":[{"url":"https://mintscan.io"},{}]}`},
+		{"apis", `{"apis":{"rest":[{"address":"https://lcd.osmosis.zone"}],"rpc":[{"address":"https://rpc.osmosis.zone","provider":"Osmosis"}]}}`},
+		{"missing, chain_id.nested", `{"chain_id":"osmosis-1"}`},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/v1/chain/osmosis", nil)
+		q := req.URL.Query()
+		if tc.fields != "" {
+			q.Set("fields", tc.fields)
+		}
+		req.URL.RawQuery = q.Encode()
+		rec := httptest.NewRecorder()
+		FieldProjection(next).ServeHTTP(rec, req)
+		if got := rec.Body.String(); got != tc.want {
+			t.Errorf("fields %q:\n got %s\nwant %s", tc.fields, got, tc.want)
+		}
+	}
+}
+
+func TestFieldProjectionSkipsErrors(t *testing.T) {
+	next := http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-Type", "application/json")
+		res.WriteHeader(http.StatusNotFound)
+		_, _ = res.Write([]byte(`{"error":"not found"}`))
+	})
+	rec := httptest.NewRecorder()
+	FieldProjection(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/chain/nope?fields=chain_id", nil))
+	if rec.Code != http.StatusNotFound || rec.Body.String() != `{"error":"not found"}` {
+		t.Errorf("error response was rewritten: %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+// chainFilter restricts the chains that are pulled and served. An empty
+// allowlist allows every chain that isn't denied.
+type chainFilter struct {
+	allow map[string]bool
+	deny  map[string]bool
+}
+
+// SetChainFilter restricts the registry to the chains in allow, if any, that
+// are not in deny. Chains are identified by their directory in the registry.
+func (h *Handler) SetChainFilter(allow, deny []string) {
+	h.filter = chainFilter{allow: set(allow), deny: set(deny)}
+}
+
+func (f chainFilter) allowed(name string) bool {
+	return (len(f.allow) == 0 || f.allow[name]) && !f.deny[name]
+}
+
+// apply returns the chains that are allowed
+func (f chainFilter) apply(names []string) []string {
+	allowed := make([]string, 0, len(names))
+	for _, name := range names {
+		if f.allowed(name) {
+			allowed = append(allowed, name)
+		}
+	}
+	return allowed
+}
+
+func set(values []string) map[string]bool {
+	s := make(map[string]bool, len(values))
+	for _, value := range values {
+		s[value] = true
+	}
+	return s
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Primary is a SharedStore that follows another skychart server, the primary,
+// by loading its registry from /replication/snapshot whenever it changes. A
+// server following a primary never pulls the registry itself, so that a fleet
+// of read replicas makes no requests upstream.
+type Primary struct {
+	// URL of the primary, e.g. https://skychart.example.com
+	URL string
+	// APIKey is sent to the primary if it requires the replication scope
+	APIKey string
+	// Client makes the requests to the primary. Defaults to the client
+	// shared by skychart.
+	Client *http.Client
+
+	mtx      sync.Mutex
+	snapshot Snapshot
+}
+
+var _ SharedStore = (*Primary)(nil)
+
+// NewPrimary follows the skychart server at url
+func NewPrimary(url, apiKey string) *Primary {
+	return &Primary{URL: strings.TrimSuffix(url, "/"), APIKey: apiKey}
+}
+
+// Lead never takes the lease, leaving the pulls to the primary
+func (p *Primary) Lead(_ context.Context, _ string, _ time.Duration) (bool, error) {
+	return false, nil
+}
+
+// Updated fetches the primary's registry if it changed since it was last
+// fetched and returns its timestamp
+func (p *Primary) Updated(ctx context.Context) (time.Time, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if err := p.fetch(ctx); err != nil {
+		return time.Time{}, err
+	}
+	return p.snapshot.Timestamp, nil
+}
+
+// Load returns the primary's registry as last fetched, fetching it first if it
+// hasn't been yet
+func (p *Primary) Load(ctx context.Context) (Snapshot, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.snapshot.Timestamp.IsZero() {
+		if err := p.fetch(ctx); err != nil {
+			return Snapshot{}, err
+		}
+	}
+	return p.snapshot, nil
+}
+
+// Save is a no-op as the primary's registry can only change by its own pulls
+func (p *Primary) Save(_ context.Context, _ Snapshot) error {
+	return errors.New("the registry of a follower can't be saved")
+}
+
+// fetch fetches the primary's registry unless it is unchanged. The caller must
+// hold the lock.
+func (p *Primary) fetch(ctx context.Context) error {
+	query := p.URL + "/replication/snapshot"
+	if !p.snapshot.Timestamp.IsZero() {
+		query += "?since=" + url.QueryEscape(p.snapshot.Timestamp.Format(time.RFC3339Nano))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, query, nil)
+	if err != nil {
+		return err
+	}
+	if p.APIKey != "" {
+		req.Header.Set(apiKeyHeader, p.APIKey)
+	}
+	resp, err := httpClient(p.Client).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil
+	case http.StatusOK:
+	default:
+		return fmt.Errorf("unexpected status code from query %s: %d", query, resp.StatusCode)
+	}
+	var snapshot Snapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return fmt.Errorf("decoding snapshot of %s: %w", p.URL, err)
+	}
+	p.snapshot = snapshot
+	return nil
+}
+
+// ReplicationSnapshot responds with a snapshot of the entire registry for the
+// servers following this one, or with 304 Not Modified if the registry hasn't
+// been updated after ?since=
+func (h *Handler) ReplicationSnapshot(res http.ResponseWriter, req *http.Request) {
+	if since := req.URL.Query().Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339Nano, since)
+		if err != nil {
+			badRequest(res)
+			return
+		}
+		if !h.updated().After(t) {
+			res.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	respondWithJSON(res, h.Snapshot())
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// GasPrices returns the fee tokens of every chain that lists any, keyed by
+// chain name
+func (h *Handler) GasPrices(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	prices := make(map[string][]types.FeeTokenElement)
+	for name, chain := range h.chainList {
+		if tokens := feeTokens(chain); len(tokens) > 0 {
+			prices[name] = tokens
+		}
+	}
+	h.respond(res, req, prices)
+}
+
+// ChainGasPrice returns the fee tokens of a chain with their gas prices, and
+// the asset that those that are IBC denominations were transferred from. It is
+// served as both /fees and /gas-price.
+func (h *Handler) ChainGasPrice(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+
+	exists, chain := h.findChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	name, _ := h.resolveChain(chainName)
+	h.respond(res, req, h.resolveFeeTokens(name, feeTokens(chain)))
+}
+
+// resolveFeeTokens resolves the fee tokens that are IBC denominations to the
+// asset they were transferred from, as registered in the chain's asset list.
+// The caller must hold the read lock.
+func (h *Handler) resolveFeeTokens(chainName string, tokens []types.FeeTokenElement) []types.FeeToken {
+	resolved := make([]types.FeeToken, len(tokens))
+	for i, token := range tokens {
+		resolved[i] = types.FeeToken{FeeTokenElement: token}
+		if !strings.HasPrefix(token.Denom, "ibc/") {
+			continue
+		}
+		asset, ok := h.ibcAsset(chainName, token.Denom)
+		if !ok {
+			continue
+		}
+		origin := &types.FeeTokenOrigin{
+			Display: asset.Display,
+			Chain:   asset.Ibc.SourceChain,
+			Denom:   asset.Ibc.SourceDenom,
+		}
+		if asset.Symbol != nil {
+			origin.Symbol = *asset.Symbol
+		}
+		if origin.Chain == "" {
+			origin.Chain = h.chainWithBase(asset.Ibc.SourceDenom)
+		}
+		resolved[i].Origin = origin
+	}
+	return resolved
+}
+
+// ibcAsset returns the asset of the chain's asset list with the ibc/ denom,
+// either as its base or as the hash of its transfer path. The caller must hold
+// the read lock.
+func (h *Handler) ibcAsset(chainName, denom string) (types.AssetElement, bool) {
+	for _, asset := range h.assetList[chainName].Assets {
+		if asset.Ibc == nil {
+			continue
+		}
+		if strings.EqualFold(asset.Base, denom) || strings.EqualFold(ibcDenom(asset.Ibc.DstChannel, asset.Ibc.SourceDenom), denom) {
+			return asset, true
+		}
+	}
+	return types.AssetElement{}, false
+}
+
+// ibcDenom returns the denom of a token received over the transfer channel,
+// e.g. ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2
+// for uatom received over channel-0 of osmosis
+func ibcDenom(channel, baseDenom string) string {
+	hash := sha256.Sum256([]byte("transfer/" + channel + "/" + baseDenom))
+	return "ibc/" + strings.ToUpper(hex.EncodeToString(hash[:]))
+}
+
+// chainWithBase returns the only chain that registers a native asset with the
+// base denom, or an empty string if none or several do. The caller must hold
+// the read lock.
+func (h *Handler) chainWithBase(base string) string {
+	found := ""
+	for name, assetList := range h.assetList {
+		for _, asset := range assetList.Assets {
+			if asset.Ibc != nil || asset.Base != base {
+				continue
+			}
+			if found != "" && found != name {
+				return ""
+			}
+			found = name
+		}
+	}
+	return found
+}
+
+func feeTokens(chain types.Chain) []types.FeeTokenElement {
+	if chain.Fees == nil {
+		return []types.FeeTokenElement{}
+	}
+	return chain.Fees.FeeTokens
+}
+
+// AppConfig returns the minimum-gas-prices setting of app.toml suggested by
+// the chain's fee tokens. ?format=json returns it as JSON instead of TOML.
+func (h *Handler) AppConfig(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	format := req.URL.Query().Get("format")
+	if format != "" && format != "toml" && format != "json" {
+		badRequest(res)
+		return
+	}
+	exists, chain := h.findChain(mux.Vars(req)["chain"])
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	config := types.AppConfig{MinimumGasPrices: minimumGasPrices(feeTokens(chain))}
+	if format == "json" {
+		respondWithJSON(res, config)
+		return
+	}
+	respondWithText(res, "application/toml", "minimum-gas-prices = "+tomlString(config.MinimumGasPrices)+"\n")
+}
+
+// minimumGasPrices formats the fee tokens as the comma separated list of
+// prices used by app.toml, e.g. 0.0025uosmo. Each token is priced at its
+// fixed minimum gas price or, if that is zero or unset, its low gas price.
+// Tokens without either are left out.
+func minimumGasPrices(tokens []types.FeeTokenElement) string {
+	prices := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		price := token.FixedMinGasPrice
+		if (price == nil || *price == 0) && token.LowGasPrice != nil {
+			price = token.LowGasPrice
+		}
+		if price == nil {
+			continue
+		}
+		prices = append(prices, strconv.FormatFloat(*price, 'f', -1, 64)+token.Denom)
+	}
+	return strings.Join(prices, ",")
+}
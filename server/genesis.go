@@ -0,0 +1,470 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// genesisChecksumTTL is how long the checksum published for a genesis
+	// file, or its absence, is kept before pulls look it up again
+	genesisChecksumTTL = 24 * time.Hour
+	// maxGenesisChecksums bounds the checksums kept by the cache, evicting the
+	// oldest first
+	maxGenesisChecksums = 1024
+	// maxChecksumWorkers bounds the checksums looked up at once by a pull
+	maxChecksumWorkers = 8
+)
+
+// genesisCache keeps downloaded genesis files on disk, named by the hash of
+// their URL, so that they are only fetched from upstream once
+type genesisCache struct {
+	client *http.Client
+
+	mtx sync.Mutex
+	// dir is created on first use, see directory
+	dir string
+	// downloads serializes the downloads of each URL. Entries are removed once
+	// no request waits for them.
+	downloads map[string]*genesisDownload
+	// checksums holds the checksums published for each URL, looked up by
+	// pulls, see refreshChecksums
+	checksums map[string]genesisSum
+	// sums holds the sha256 of the cached file of each URL, computed when it
+	// is downloaded or first served
+	sums map[string]string
+}
+
+// genesisSum is the sha256 published for a genesis file, empty if there is
+// none, and when it was looked up
+type genesisSum struct {
+	sha256  string
+	fetched time.Time
+}
+
+func newGenesisCache(dir string, client *http.Client) *genesisCache {
+	return &genesisCache{
+		dir:       dir,
+		client:    client,
+		downloads: make(map[string]*genesisDownload),
+		checksums: make(map[string]genesisSum),
+		sums:      make(map[string]string),
+	}
+}
+
+// SetGenesisDir sets the directory genesis files are cached in. It defaults to
+// skychart/genesis in the user's cache directory, or a new temporary directory
+// if there is none.
+func (h *Handler) SetGenesisDir(dir string) {
+	h.genesis = newGenesisCache(dir, h.client)
+}
+
+// file returns the path of the cached genesis file at url, downloading it
+// first if needed. A cached file that no longer matches the checksum last
+// looked up for url is downloaded again.
+func (c *genesisCache) file(ctx context.Context, url string) (string, error) {
+	dir, err := c.directory()
+	if err != nil {
+		return "", err
+	}
+	name := filepath.Join(dir, genesisFileName(url))
+
+	c.mtx.Lock()
+	download, ok := c.downloads[url]
+	if !ok {
+		download = &genesisDownload{}
+		c.downloads[url] = download
+	}
+	download.waiting++
+	c.mtx.Unlock()
+	download.Lock()
+	defer func() {
+		download.Unlock()
+		c.mtx.Lock()
+		if download.waiting--; download.waiting == 0 {
+			delete(c.downloads, url)
+		}
+		c.mtx.Unlock()
+	}()
+
+	if _, err := os.Stat(name); err == nil {
+		current, err := c.current(url, name)
+		if err != nil {
+			return "", err
+		}
+		if current {
+			return name, nil
+		}
+	}
+	return name, c.download(ctx, url, dir, name)
+}
+
+// genesisFileName returns the name of the cached genesis file at url
+func genesisFileName(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(hash[:])
+}
+
+// current reports whether the cached file of url, at name, matches the
+// checksum last looked up for url. Files without a checksum are current. The
+// file is only hashed the first time it is checked, which callers serialize
+// through the download lock of url.
+func (c *genesisCache) current(url, name string) (bool, error) {
+	checksum, ok := c.cachedChecksum(url)
+	if !ok || checksum == "" {
+		return true, nil
+	}
+	c.mtx.Lock()
+	sum, ok := c.sums[url]
+	c.mtx.Unlock()
+	if !ok {
+		f, err := os.Open(name)
+		if err != nil {
+			return false, err
+		}
+		defer f.Close()
+		hash := sha256.New()
+		if _, err := io.Copy(hash, f); err != nil {
+			return false, err
+		}
+		sum = hex.EncodeToString(hash.Sum(nil))
+		c.mtx.Lock()
+		c.sums[url] = sum
+		c.mtx.Unlock()
+	}
+	return strings.EqualFold(checksum, sum), nil
+}
+
+// prune removes the cached files, and the checksums, of the URLs that aren't
+// in urls, so that genesis files dropped from the registry don't fill the
+// disk. Downloads in progress are left alone. The default directory is only
+// pruned once the cache has used it, so that a handler that never serves
+// genesis files doesn't touch the files of another.
+func (c *genesisCache) prune(urls []string) error {
+	c.mtx.Lock()
+	dir := c.dir
+	c.mtx.Unlock()
+	keep := make(map[string]bool, len(urls))
+	names := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		keep[url] = true
+		names[genesisFileName(url)] = true
+	}
+	c.mtx.Lock()
+	for url := range c.sums {
+		if !keep[url] {
+			delete(c.sums, url)
+		}
+	}
+	for url := range c.checksums {
+		if !keep[url] {
+			delete(c.checksums, url)
+		}
+	}
+	c.mtx.Unlock()
+
+	if dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if names[entry.Name()] || strings.HasPrefix(entry.Name(), "download-") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// genesisDownload serializes the downloads of a URL
+type genesisDownload struct {
+	sync.Mutex
+	// waiting counts the requests holding or waiting for the lock, guarded by
+	// the lock of the cache
+	waiting int
+}
+
+// directory returns the directory of the cache, creating it if needed. Only
+// the owner can access the directories it creates, so that other users can't
+// swap the files that are served.
+func (c *genesisCache) directory() (string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.dir == "" {
+		if cache, err := os.UserCacheDir(); err == nil {
+			c.dir = filepath.Join(cache, "skychart", "genesis")
+		} else if c.dir, err = os.MkdirTemp("", "skychart-genesis-"); err != nil {
+			return "", err
+		}
+	}
+	return c.dir, os.MkdirAll(c.dir, 0o700)
+}
+
+// download fetches the genesis file into a temporary file, checks it against
+// the checksum published next to it, if any, and then moves it into place
+func (c *genesisCache) download(ctx context.Context, url, dir, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code for genesis %s: %d", url, resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(dir, "download-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	checksum, err := c.checksum(ctx, url)
+	if err != nil {
+		return err
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if checksum != "" && !strings.EqualFold(checksum, sum) {
+		return fmt.Errorf("genesis %s has sha256 %s, expected %s", url, sum, checksum)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return err
+	}
+	c.mtx.Lock()
+	c.sums[url] = sum
+	c.mtx.Unlock()
+	return nil
+}
+
+// checksum returns the sha256 published for the genesis file at url, looking
+// it up unless a pull did so within genesisChecksumTTL
+func (c *genesisCache) checksum(ctx context.Context, url string) (string, error) {
+	if checksum, ok := c.cachedChecksum(url); ok {
+		return checksum, nil
+	}
+	checksum, err := genesisChecksum(ctx, c.client, url)
+	if err != nil {
+		return "", err
+	}
+	c.storeChecksum(url, checksum)
+	return checksum, nil
+}
+
+// cachedChecksum returns the sha256 last looked up for the genesis file at
+// url, if it hasn't expired
+func (c *genesisCache) cachedChecksum(url string) (string, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	sum, ok := c.checksums[url]
+	if !ok || time.Since(sum.fetched) > genesisChecksumTTL {
+		return "", false
+	}
+	return sum.sha256, true
+}
+
+// storeChecksum records the sha256 looked up for the genesis file at url,
+// evicting the oldest checksum if the cache is full
+func (c *genesisCache) storeChecksum(url, checksum string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.checksums[url] = genesisSum{sha256: checksum, fetched: time.Now()}
+	if len(c.checksums) <= maxGenesisChecksums {
+		return
+	}
+	oldest := url
+	for u, sum := range c.checksums {
+		if sum.fetched.Before(c.checksums[oldest].fetched) {
+			oldest = u
+		}
+	}
+	delete(c.checksums, oldest)
+}
+
+// refreshChecksums looks up the checksums of the genesis files at urls that
+// aren't cached or have expired, so that requests never wait for them. It
+// returns the number of lookups that failed, which are retried by the next
+// pull.
+func (c *genesisCache) refreshChecksums(ctx context.Context, urls []string) int {
+	var (
+		wg     sync.WaitGroup
+		failed int64
+	)
+	workers := make(chan struct{}, maxChecksumWorkers)
+	for _, url := range urls {
+		if _, ok := c.cachedChecksum(url); ok {
+			continue
+		}
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(url string) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			checksum, err := genesisChecksum(ctx, c.client, url)
+			if err != nil {
+				atomic.AddInt64(&failed, 1)
+				return
+			}
+			c.storeChecksum(url, checksum)
+		}(url)
+	}
+	wg.Wait()
+	return int(failed)
+}
+
+// refreshChecksums looks up the checksums of the genesis files of the chains
+// that aren't cached or have expired
+func (h *Handler) refreshChecksums(ctx context.Context, chains map[string]types.Chain) {
+	if failed := h.genesis.refreshChecksums(ctx, genesisURLs(chains)); failed > 0 {
+		h.log.Printf("failed to look up %d genesis checksums", failed)
+	}
+}
+
+// pruneGenesis removes the cached genesis files of the chains that are no
+// longer in the registry, or that moved their genesis to another URL
+func (h *Handler) pruneGenesis() {
+	h.mtx.RLock()
+	urls := genesisURLs(h.chainList)
+	h.mtx.RUnlock()
+	if err := h.genesis.prune(urls); err != nil {
+		h.log.Printf("failed to prune genesis files: %s", err)
+	}
+}
+
+// genesisURLs returns the genesis URLs of the chains that have one
+func genesisURLs(chains map[string]types.Chain) []string {
+	urls := make([]string, 0, len(chains))
+	for _, chain := range chains {
+		if chain.Genesis != nil && chain.Genesis.GenesisURL != nil && *chain.Genesis.GenesisURL != "" {
+			urls = append(urls, *chain.Genesis.GenesisURL)
+		}
+	}
+	return urls
+}
+
+// genesisChecksum returns the sha256 published at url with a .sha256
+// extension, in the format of sha256sum. It returns an empty string if there
+// is none.
+func genesisChecksum(ctx context.Context, client *http.Client, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+".sha256", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", nil
+	}
+	line, err := bufio.NewReader(io.LimitReader(resp.Body, 1024)).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", nil
+	}
+	return fields[0], nil
+}
+
+// Genesis serves the chain's genesis file from the local cache, downloading
+// and verifying it on the first request
+func (h *Handler) Genesis(res http.ResponseWriter, req *http.Request) {
+	// the lock is released before the genesis file is fetched
+	h.mtx.RLock()
+	exists, chain := h.findChain(mux.Vars(req)["chain"])
+	h.mtx.RUnlock()
+
+	if !exists || chain.Genesis == nil || chain.Genesis.GenesisURL == nil || *chain.Genesis.GenesisURL == "" {
+		resourceNotFound(res)
+		return
+	}
+	url := *chain.Genesis.GenesisURL
+	name, err := h.genesis.file(req.Context(), url)
+	if err != nil {
+		h.log.Printf("fetching genesis of %s: %v", chain.ChainName, err)
+		badGateway(res)
+		return
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		h.log.Printf("opening genesis of %s: %v", chain.ChainName, err)
+		badGateway(res)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		badGateway(res)
+		return
+	}
+	setCORSHeaders(res)
+	res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(url)))
+	// the content type is derived from the name, e.g. application/gzip
+	http.ServeContent(res, req, path.Base(url), info.ModTime(), f)
+}
+
+// GenesisInfo returns the genesis URL of the chain along with the checksum
+// published next to it and the height the chain was launched at, if known, so
+// that nodes can be bootstrapped without downloading the genesis file through
+// the server. Checksums are looked up by pulls rather than by requests.
+func (h *Handler) GenesisInfo(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	exists, chain := h.findChain(mux.Vars(req)["chain"])
+	if !exists || chain.Genesis == nil || chain.Genesis.GenesisURL == nil || *chain.Genesis.GenesisURL == "" {
+		resourceNotFound(res)
+		return
+	}
+	info := types.GenesisInfo{
+		ChainName:  chain.ChainName,
+		ChainID:    chain.ChainID,
+		GenesisURL: *chain.Genesis.GenesisURL,
+	}
+	if chain.Codebase != nil && len(chain.Codebase.Versions) > 0 {
+		if height := chain.Codebase.Versions[0].Height; height != nil && *height > 0 {
+			info.InitialHeight = height
+		}
+	}
+	info.Sha256, _ = h.genesis.cachedChecksum(info.GenesisURL)
+	h.respond(res, req, info)
+}
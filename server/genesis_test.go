@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenesisCache(t *testing.T) {
+	var mtx sync.Mutex
+	downloads := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if filepath.Ext(req.URL.Path) == ".sha256" {
+			http.NotFound(res, req)
+			return
+		}
+		mtx.Lock()
+		downloads++
+		mtx.Unlock()
+		_, _ = res.Write([]byte(`{"chain_id":"osmosis-1"}`))
+	}))
+	defer upstream.Close()
+
+	dir := filepath.Join(t.TempDir(), "genesis")
+	c := newGenesisCache(dir, upstream.Client())
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.file(context.Background(), upstream.URL+"/genesis.json"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if downloads != 1 {
+		t.Errorf("genesis was downloaded %d times, want 1", downloads)
+	}
+	if len(c.downloads) != 0 {
+		t.Errorf("%d downloads are still tracked once done", len(c.downloads))
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o700 {
+		t.Errorf("cache directory has permissions %o, want 700", perm)
+	}
+}
+
+func TestGenesisChecksums(t *testing.T) {
+	const sum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	var mtx sync.Mutex
+	lookups := 0
+	published := false
+	upstream := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		lookups++
+		if !published {
+			http.NotFound(res, req)
+			return
+		}
+		fmt.Fprintf(res, "%s  genesis.json\n", sum)
+	}))
+	defer upstream.Close()
+
+	c := newGenesisCache(t.TempDir(), upstream.Client())
+	url := upstream.URL + "/genesis.json"
+	if failed := c.refreshChecksums(context.Background(), []string{url}); failed != 0 {
+		t.Fatalf("%d lookups failed", failed)
+	}
+	if checksum, ok := c.cachedChecksum(url); !ok || checksum != "" {
+		t.Errorf("missing checksum cached as %q, %v", checksum, ok)
+	}
+	mtx.Lock()
+	published = true
+	mtx.Unlock()
+	c.refreshChecksums(context.Background(), []string{url})
+	if lookups != 1 {
+		t.Errorf("checksum was looked up %d times before it expired, want 1", lookups)
+	}
+
+	// an expired absence is looked up again
+	c.checksums[url] = genesisSum{fetched: time.Now().Add(-genesisChecksumTTL - time.Minute)}
+	c.refreshChecksums(context.Background(), []string{url})
+	if checksum, _ := c.cachedChecksum(url); checksum != sum {
+		t.Errorf("checksum %q after expiry, want %s", checksum, sum)
+	}
+
+	for i := 0; i < maxGenesisChecksums+10; i++ {
+		c.storeChecksum(fmt.Sprintf("https://example.com/%d/genesis.json", i), sum)
+	}
+	if len(c.checksums) != maxGenesisChecksums {
+		t.Errorf("%d checksums cached, want at most %d", len(c.checksums), maxGenesisChecksums)
+	}
+	if _, ok := c.checksums[url]; ok {
+		t.Error("oldest checksum wasn't evicted")
+	}
+}
+
+func TestGenesisCacheChecksumChanged(t *testing.T) {
+	var mtx sync.Mutex
+	genesis := `{"chain_id":"osmosis-1"}`
+	upstream := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if filepath.Ext(req.URL.Path) == ".sha256" {
+			http.NotFound(res, req)
+			return
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		_, _ = res.Write([]byte(genesis))
+	}))
+	defer upstream.Close()
+
+	c := newGenesisCache(t.TempDir(), upstream.Client())
+	url := upstream.URL + "/genesis.json"
+	if _, err := c.file(context.Background(), url); err != nil {
+		t.Fatal(err)
+	}
+
+	// the genesis is replaced upstream and a pull looks up its new checksum
+	mtx.Lock()
+	genesis = `{"chain_id":"osmosis-2"}`
+	mtx.Unlock()
+	sum := sha256.Sum256([]byte(`{"chain_id":"osmosis-2"}`))
+	c.storeChecksum(url, hex.EncodeToString(sum[:]))
+
+	name, err := c.file(context.Background(), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bz, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bz) != `{"chain_id":"osmosis-2"}` {
+		t.Errorf("cached genesis %s wasn't downloaded again", bz)
+	}
+}
+
+func TestGenesisCachePrune(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if filepath.Ext(req.URL.Path) == ".sha256" {
+			http.NotFound(res, req)
+			return
+		}
+		_, _ = res.Write([]byte(`{}`))
+	}))
+	defer upstream.Close()
+
+	dir := t.TempDir()
+	c := newGenesisCache(dir, upstream.Client())
+	kept, dropped := upstream.URL+"/osmosis/genesis.json", upstream.URL+"/juno/genesis.json"
+	keptName, err := c.file(context.Background(), kept)
+	if err != nil {
+		t.Fatal(err)
+	}
+	droppedName, err := c.file(context.Background(), dropped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	download := filepath.Join(dir, "download-123")
+	if err := os.WriteFile(download, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.prune([]string{kept}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{keptName, download} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("%s was pruned: %v", filepath.Base(name), err)
+		}
+	}
+	if _, err := os.Stat(droppedName); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("genesis dropped from the registry wasn't pruned: %v", err)
+	}
+	if _, ok := c.sums[dropped]; ok {
+		t.Error("sum of the dropped genesis is still cached")
+	}
+}
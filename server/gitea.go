@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// GiteaSource pulls the registry from a repo hosted on Gitea, or its forks
+// such as Forgejo, through its REST API
+type GiteaSource struct {
+	// URL of the Gitea instance, e.g. https://gitea.example.com
+	URL string
+	// Repo is the owner and name of the repo, e.g. infra/chain-registry
+	Repo   string
+	Branch string
+	// Token is an access token. It is only needed for private repos.
+	Token string
+	// Client makes the requests. Defaults to a client shared by skychart.
+	Client *http.Client
+}
+
+var _ Source = GiteaSource{}
+
+// Changed compares the time of the latest commit as not every version of
+// Gitea can filter commits by time
+func (g GiteaSource) Changed(ctx context.Context, since time.Time) (bool, error) {
+	query := fmt.Sprintf("%s/commits?sha=%s&limit=1", g.repoUrl(), url.QueryEscape(g.Branch))
+	var commits []struct {
+		Created time.Time `json:"created"`
+	}
+	if err := g.getJSON(ctx, query, &commits); err != nil {
+		return false, err
+	}
+	return len(commits) > 0 && commits[0].Created.After(since), nil
+}
+
+func (g GiteaSource) Commit(ctx context.Context) (string, error) {
+	query := fmt.Sprintf("%s/commits?sha=%s&limit=1", g.repoUrl(), url.QueryEscape(g.Branch))
+	var commits []struct {
+		SHA string `json:"sha"`
+	}
+	if err := g.getJSON(ctx, query, &commits); err != nil {
+		return "", err
+	}
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits on branch %s", g.Branch)
+	}
+	return commits[0].SHA, nil
+}
+
+// At returns the source reading the repo at the commit
+func (g GiteaSource) At(commit string) Source {
+	g.Branch = commit
+	return g
+}
+
+func (g GiteaSource) Chains(ctx context.Context) ([]string, error) {
+	query := fmt.Sprintf("%s/contents?ref=%s", g.repoUrl(), url.QueryEscape(g.Branch))
+	var contents []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := g.getJSON(ctx, query, &contents); err != nil {
+		return nil, err
+	}
+	chains := make([]string, 0)
+	for _, entry := range contents {
+		if entry.Type == "dir" && isChainDir(entry.Name) {
+			chains = append(chains, entry.Name)
+		}
+	}
+	return chains, nil
+}
+
+func (g GiteaSource) File(ctx context.Context, chain, file string) ([]byte, bool, error) {
+	return fetch(ctx, httpClient(g.Client), g.header(), g.fileUrl(chain, file), nil)
+}
+
+// RootFile returns a file at the root of the repo at the commit, or the branch
+// if it is empty
+func (g GiteaSource) RootFile(ctx context.Context, commit, file string) ([]byte, bool, error) {
+	ref := g.Branch
+	if commit != "" {
+		ref = commit
+	}
+	query := fmt.Sprintf("%s/raw/%s?ref=%s", g.repoUrl(), url.PathEscape(file), url.QueryEscape(ref))
+	return fetch(ctx, httpClient(g.Client), g.header(), query, nil)
+}
+
+func (g GiteaSource) OpenFile(ctx context.Context, chain, file string) (io.ReadCloser, bool, error) {
+	return stream(ctx, httpClient(g.Client), g.header(), g.fileUrl(chain, file), nil)
+}
+
+// Tree writes every file of the repo at the commit into dir
+func (g GiteaSource) Tree(ctx context.Context, commit, dir string) error {
+	query := fmt.Sprintf("%s/archive/%s.tar.gz", g.repoUrl(), url.PathEscape(commit))
+	return downloadTree(ctx, httpClient(g.Client), g.header(), query, dir)
+}
+
+func (g GiteaSource) fileUrl(chain, file string) string {
+	return fmt.Sprintf("%s/raw/%s/%s?ref=%s", g.repoUrl(), url.PathEscape(chain), url.PathEscape(file), url.QueryEscape(g.Branch))
+}
+
+func (g GiteaSource) repoUrl() string {
+	return fmt.Sprintf("%s/api/v1/repos/%s", g.URL, g.Repo)
+}
+
+func (g GiteaSource) header() http.Header {
+	header := http.Header{}
+	if g.Token != "" {
+		header.Set("Authorization", "token "+g.Token)
+	}
+	return header
+}
+
+func (g GiteaSource) getJSON(ctx context.Context, query string, v interface{}) error {
+	bz, found, err := fetch(ctx, httpClient(g.Client), g.header(), query, nil)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("repo %s or branch %s not found", g.Repo, g.Branch)
+	}
+	return json.Unmarshal(bz, v)
+}
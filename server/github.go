@@ -0,0 +1,335 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// githubSource pulls the registry from a github repo through the github api
+// and raw content host. If archive is set the files are read from a tarball
+// of the repo and if blobs is set they are fetched through the GraphQL API.
+type githubSource struct {
+	repo    string
+	branch  string
+	apiUrl  string
+	rawUrl  string
+	token   string
+	quota   *quota
+	client  *http.Client
+	archive *archive
+	blobs   *blobs
+}
+
+// quotaReserve is the number of api requests that a pull leaves for the next
+// pull to check for recent commits and look up the latest commit
+const quotaReserve = 2
+
+// contentsLimit is the most entries the contents API lists for a directory
+const contentsLimit = 1000
+
+// quota is the github api rate limit as reported by the headers of the last
+// response
+type quota struct {
+	mtx       sync.Mutex
+	known     bool
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// observe records the rate limit headers, which are only sent by the api
+// and not the raw content host
+func (q *quota) observe(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.known = true
+	q.limit = limit
+	q.remaining = remaining
+	q.reset = time.Unix(reset, 0)
+}
+
+// rateLimit returns the last reported rate limit, or nil if github hasn't
+// reported one yet
+func (q *quota) rateLimit() *types.RateLimit {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if !q.known {
+		return nil
+	}
+	return &types.RateLimit{Limit: q.limit, Remaining: q.remaining, Reset: q.reset}
+}
+
+// throttle delays an api request once less than a tenth of the quota remains,
+// spreading the remaining requests evenly until the quota resets. Rather than
+// spend the requests kept in reserve, it returns a RateLimitError so that the
+// pull is retried after the reset.
+func (q *quota) throttle(ctx context.Context, query string) error {
+	q.mtx.Lock()
+	known, limit, remaining, reset := q.known, q.limit, q.remaining, q.reset
+	q.mtx.Unlock()
+	untilReset := time.Until(reset)
+	if !known || remaining > limit/10 || untilReset <= 0 {
+		return nil
+	}
+	if remaining <= quotaReserve {
+		return &RateLimitError{Query: query, Reset: reset}
+	}
+
+	timer := time.NewTimer(untilReset / time.Duration(remaining-quotaReserve))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var _ Source = githubSource{}
+
+// header authenticates requests with the token, if any
+func (g githubSource) header() http.Header {
+	if g.token == "" {
+		return nil
+	}
+	return http.Header{"Authorization": {"Bearer " + g.token}}
+}
+
+// fetchAPI fetches a query of the github api once the quota allows it
+func (g githubSource) fetchAPI(ctx context.Context, query string) ([]byte, bool, error) {
+	if err := g.quota.throttle(ctx, query); err != nil {
+		return nil, false, err
+	}
+	return fetch(ctx, g.client, g.header(), query, g.quota.observe)
+}
+
+// Changed returns true if there has been a commit since the given time. It
+// isn't throttled as it spends the requests that pulls keep in reserve.
+func (g githubSource) Changed(ctx context.Context, since time.Time) (bool, error) {
+	query := fmt.Sprintf("%s/repos/%s/commits?sha=%s&since=%s", g.apiUrl, g.repo, g.branch, since.Format(time.RFC3339))
+	bodyBytes, found, err := fetch(ctx, g.client, g.header(), query, g.quota.observe)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, fmt.Errorf("unexpected status code for query %s: %d", query, http.StatusNotFound)
+	}
+
+	var body []interface{}
+	err = json.Unmarshal(bodyBytes, &body)
+	if err != nil {
+		return false, err
+	}
+
+	return len(body) > 0, nil
+}
+
+func (g githubSource) Commit(ctx context.Context) (string, error) {
+	query := fmt.Sprintf("%s/repos/%s/commits/%s", g.apiUrl, g.repo, g.branch)
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	bodyBytes, found, err := g.fetchAPI(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("unexpected status code for query %s: %d", query, http.StatusNotFound)
+	}
+	if err := json.Unmarshal(bodyBytes, &commit); err != nil {
+		return "", err
+	}
+	return commit.SHA, nil
+}
+
+// At returns the source reading the repo at the commit, through the contents
+// and trees APIs, the raw content host, the tarball and the GraphQL API alike
+func (g githubSource) At(commit string) Source {
+	g.branch = commit
+	return g
+}
+
+func (g githubSource) Chains(ctx context.Context) ([]string, error) {
+	if g.archive != nil {
+		if err := g.archive.load(ctx, g); err != nil {
+			return nil, err
+		}
+		return g.archive.chains(), nil
+	}
+
+	query := fmt.Sprintf("%s/repos/%s/contents?ref=%s", g.apiUrl, g.repo, g.branch)
+	bodyBytes, found, err := g.fetchAPI(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("unexpected status code from query %s: %d", query, http.StatusNotFound)
+	}
+
+	var repo []map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &repo); err != nil {
+		return nil, fmt.Errorf("unmarshalling repo: %w", err)
+	}
+	// the contents API lists at most 1000 entries of a directory, past
+	// which the listing is silently truncated
+	if len(repo) >= contentsLimit {
+		return g.treeChains(ctx)
+	}
+
+	chains := make([]string, 0)
+	for _, entry := range repo {
+		// only accept directories
+		entryType := entry["type"].(string)
+		if entryType != "dir" {
+			continue
+		}
+
+		name := entry["name"].(string)
+		if !isChainDir(name) {
+			continue
+		}
+
+		chains = append(chains, name)
+	}
+	return chains, nil
+}
+
+// treeChains lists the chains through the git trees API, which isn't limited
+// to 1000 entries like the contents API
+func (g githubSource) treeChains(ctx context.Context) ([]string, error) {
+	entries, err := g.tree(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	chains := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == "tree" && isChainDir(entry.Path) {
+			chains = append(chains, entry.Path)
+		}
+	}
+	return chains, nil
+}
+
+// Listing lists the files of the chain directories through the recursive git
+// trees API in a single request, or from the tarball if the registry is
+// pulled as an archive
+func (g githubSource) Listing(ctx context.Context) (map[string]bool, error) {
+	listing := make(map[string]bool)
+	if g.archive != nil {
+		if err := g.archive.load(ctx, g); err != nil {
+			return nil, err
+		}
+		for path := range g.archive.files {
+			listing[path] = true
+		}
+		return listing, nil
+	}
+	entries, err := g.tree(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Type == "blob" && strings.Count(entry.Path, "/") == 1 {
+			listing[entry.Path] = true
+		}
+	}
+	return listing, nil
+}
+
+type treeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// tree lists the entries at the root of the repo through the git trees API,
+// or every entry of the repo if recursive is set
+func (g githubSource) tree(ctx context.Context, recursive bool) ([]treeEntry, error) {
+	query := fmt.Sprintf("%s/repos/%s/git/trees/%s", g.apiUrl, g.repo, g.branch)
+	if recursive {
+		query += "?recursive=1"
+	}
+	bodyBytes, found, err := g.fetchAPI(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("unexpected status code from query %s: %d", query, http.StatusNotFound)
+	}
+
+	var tree struct {
+		Tree      []treeEntry `json:"tree"`
+		Truncated bool        `json:"truncated"`
+	}
+	if err := json.Unmarshal(bodyBytes, &tree); err != nil {
+		return nil, fmt.Errorf("unmarshalling tree: %w", err)
+	}
+	if tree.Truncated {
+		return nil, fmt.Errorf("tree of %s is truncated", g.repo)
+	}
+	return tree.Tree, nil
+}
+
+// Prefetch fetches the files of the chains through the GraphQL API, if
+// enabled
+func (g githubSource) Prefetch(ctx context.Context, chains []string) error {
+	if g.blobs == nil {
+		return nil
+	}
+	return g.blobs.load(ctx, g, chains)
+}
+
+// OpenFile streams the file from the raw content host unless the files are
+// read from the tarball or GraphQL API
+func (g githubSource) OpenFile(ctx context.Context, chain, file string) (io.ReadCloser, bool, error) {
+	if g.archive != nil || g.blobs != nil {
+		return readerOf(g.File(ctx, chain, file))
+	}
+	return stream(ctx, g.client, g.header(), g.rawFileUrl(chain, file), g.quota.observe)
+}
+
+func (g githubSource) rawFileUrl(chain, file string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", g.rawUrl, g.repo, g.branch, chain, file)
+}
+
+// RootFile returns a file at the root of the repo at the commit, or the branch
+// if it is empty
+func (g githubSource) RootFile(ctx context.Context, commit, file string) ([]byte, bool, error) {
+	ref := g.branch
+	if commit != "" {
+		ref = commit
+	}
+	query := fmt.Sprintf("%s/%s/%s/%s", g.rawUrl, g.repo, ref, file)
+	return fetch(ctx, g.client, g.header(), query, g.quota.observe)
+}
+
+func (g githubSource) File(ctx context.Context, chain, file string) ([]byte, bool, error) {
+	if g.archive != nil {
+		if err := g.archive.load(ctx, g); err != nil {
+			return nil, false, err
+		}
+		bz, ok := g.archive.file(chain, file)
+		return bz, ok, nil
+	}
+	if g.blobs != nil {
+		if bz, found, ok := g.blobs.file(chain, file); ok {
+			return bz, found, nil
+		}
+	}
+	return fetch(ctx, g.client, g.header(), g.rawFileUrl(chain, file), g.quota.observe)
+}
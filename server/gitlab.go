@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// GitLabSource pulls the registry from a repo hosted on GitLab, such as a
+// self-hosted instance, through its REST API
+type GitLabSource struct {
+	// URL of the GitLab instance, e.g. https://gitlab.example.com
+	URL string
+	// Project is the path of the repo, e.g. infra/chain-registry
+	Project string
+	Branch  string
+	// Token is a personal, project or group access token. It is only needed
+	// for private repos.
+	Token string
+	// Client makes the requests. Defaults to a client shared by skychart.
+	Client *http.Client
+}
+
+var _ Source = GitLabSource{}
+
+func (g GitLabSource) Changed(ctx context.Context, since time.Time) (bool, error) {
+	query := fmt.Sprintf("%s/repository/commits?ref_name=%s&since=%s&per_page=1",
+		g.projectUrl(), url.QueryEscape(g.Branch), url.QueryEscape(since.Format(time.RFC3339)))
+	var commits []interface{}
+	if err := g.getJSON(ctx, query, &commits); err != nil {
+		return false, err
+	}
+	return len(commits) > 0, nil
+}
+
+func (g GitLabSource) Commit(ctx context.Context) (string, error) {
+	query := fmt.Sprintf("%s/repository/commits/%s", g.projectUrl(), url.PathEscape(g.Branch))
+	var commit struct {
+		ID string `json:"id"`
+	}
+	if err := g.getJSON(ctx, query, &commit); err != nil {
+		return "", err
+	}
+	return commit.ID, nil
+}
+
+// At returns the source reading the project at the commit
+func (g GitLabSource) At(commit string) Source {
+	g.Branch = commit
+	return g
+}
+
+func (g GitLabSource) Chains(ctx context.Context) ([]string, error) {
+	chains := make([]string, 0)
+	for page := 1; ; page++ {
+		query := fmt.Sprintf("%s/repository/tree?ref=%s&per_page=100&page=%d", g.projectUrl(), url.QueryEscape(g.Branch), page)
+		var tree []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		}
+		if err := g.getJSON(ctx, query, &tree); err != nil {
+			return nil, err
+		}
+		for _, entry := range tree {
+			if entry.Type == "tree" && isChainDir(entry.Name) {
+				chains = append(chains, entry.Name)
+			}
+		}
+		if len(tree) < 100 {
+			return chains, nil
+		}
+	}
+}
+
+func (g GitLabSource) File(ctx context.Context, chain, file string) ([]byte, bool, error) {
+	return fetch(ctx, httpClient(g.Client), g.header(), g.fileUrl(chain, file), nil)
+}
+
+// RootFile returns a file at the root of the repo at the commit, or the branch
+// if it is empty
+func (g GitLabSource) RootFile(ctx context.Context, commit, file string) ([]byte, bool, error) {
+	ref := g.Branch
+	if commit != "" {
+		ref = commit
+	}
+	query := fmt.Sprintf("%s/repository/files/%s/raw?ref=%s", g.projectUrl(), url.PathEscape(file), url.QueryEscape(ref))
+	return fetch(ctx, httpClient(g.Client), g.header(), query, nil)
+}
+
+func (g GitLabSource) OpenFile(ctx context.Context, chain, file string) (io.ReadCloser, bool, error) {
+	return stream(ctx, httpClient(g.Client), g.header(), g.fileUrl(chain, file), nil)
+}
+
+// Tree writes every file of the repo at the commit into dir
+func (g GitLabSource) Tree(ctx context.Context, commit, dir string) error {
+	query := fmt.Sprintf("%s/repository/archive.tar.gz?sha=%s", g.projectUrl(), url.QueryEscape(commit))
+	return downloadTree(ctx, httpClient(g.Client), g.header(), query, dir)
+}
+
+func (g GitLabSource) fileUrl(chain, file string) string {
+	return fmt.Sprintf("%s/repository/files/%s/raw?ref=%s", g.projectUrl(), url.PathEscape(chain+"/"+file), url.QueryEscape(g.Branch))
+}
+
+func (g GitLabSource) projectUrl() string {
+	return fmt.Sprintf("%s/api/v4/projects/%s", g.URL, url.PathEscape(g.Project))
+}
+
+func (g GitLabSource) header() http.Header {
+	header := http.Header{}
+	if g.Token != "" {
+		header.Set("PRIVATE-TOKEN", g.Token)
+	}
+	return header
+}
+
+func (g GitLabSource) getJSON(ctx context.Context, query string, v interface{}) error {
+	bz, found, err := fetch(ctx, httpClient(g.Client), g.header(), query, nil)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("project %s or branch %s not found", g.Project, g.Branch)
+	}
+	return json.Unmarshal(bz, v)
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// graphqlBatch bounds the number of chains whose files are requested in a
+// single GraphQL query
+const graphqlBatch = 100
+
+// blobs holds the chain files of the registry fetched in batches through
+// github's GraphQL API, which needs a token. Files that are missing are
+// recorded so that they aren't fetched again. Files that github truncates are
+// left out and fetched from the raw content host instead.
+type blobs struct {
+	mtx     sync.Mutex
+	files   map[string][]byte // chain/file -> contents
+	missing map[string]bool
+}
+
+// SetGraphQL pulls the files of the registry through github's GraphQL API in
+// a handful of queries. It requires a github token and has no effect if a
+// source is set.
+func (h *Handler) SetGraphQL(enabled bool) {
+	h.graphql = enabled
+}
+
+// SetGitHubToken authenticates the requests to github, raising its rate limit
+func (h *Handler) SetGitHubToken(token string) {
+	h.githubToken = token
+}
+
+func newBlobs() *blobs {
+	return &blobs{files: make(map[string][]byte), missing: make(map[string]bool)}
+}
+
+// load fetches the chain.json and assetlist.json of every chain
+func (b *blobs) load(ctx context.Context, g githubSource, chains []string) error {
+	for start := 0; start < len(chains); start += graphqlBatch {
+		end := start + graphqlBatch
+		if end > len(chains) {
+			end = len(chains)
+		}
+		if err := b.loadBatch(ctx, g, chains[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *blobs) loadBatch(ctx context.Context, g githubSource, chains []string) error {
+	parts := strings.SplitN(g.repo, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repo %s", g.repo)
+	}
+	files := make(map[string]string) // alias -> chain/file
+	var query strings.Builder
+	fmt.Fprintf(&query, "query { repository(owner: %s, name: %s) {", graphqlString(parts[0]), graphqlString(parts[1]))
+	for i, chain := range chains {
+		for j, file := range []string{"chain.json", "assetlist.json"} {
+			alias := fmt.Sprintf("f%d_%d", i, j)
+			files[alias] = chain + "/" + file
+			fmt.Fprintf(&query, " %s: object(expression: %s) { ... on Blob { text isTruncated } }",
+				alias, graphqlString(g.branch+":"+chain+"/"+file))
+		}
+	}
+	query.WriteString(" } }")
+
+	body, err := json.Marshal(map[string]string{"query": query.String()})
+	if err != nil {
+		return err
+	}
+	url := g.apiUrl + "/graphql"
+	if err := g.quota.throttle(ctx, url); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	g.quota.observe(resp.Header)
+	if err := rateLimited(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code for query %s: %d", url, resp.StatusCode)
+	}
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Data struct {
+			Repository map[string]*struct {
+				Text        *string `json:"text"`
+				IsTruncated bool    `json:"isTruncated"`
+			} `json:"repository"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(bz, &result); err != nil {
+		return fmt.Errorf("unmarshalling graphql response: %w", err)
+	}
+	if result.Data.Repository == nil {
+		if len(result.Errors) > 0 {
+			return errors.New(result.Errors[0].Message)
+		}
+		return fmt.Errorf("repository %s not found", g.repo)
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	for alias, file := range files {
+		blob := result.Data.Repository[alias]
+		switch {
+		case blob == nil:
+			b.missing[file] = true
+		case blob.Text != nil && !blob.IsTruncated:
+			b.files[file] = []byte(*blob.Text)
+		}
+	}
+	return nil
+}
+
+// file returns the contents of the file if it was fetched. found is false if
+// the file doesn't exist and ok is false if it has to be fetched otherwise.
+func (b *blobs) file(chain, file string) (bz []byte, found, ok bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	key := chain + "/" + file
+	if b.missing[key] {
+		return nil, false, true
+	}
+	bz, ok = b.files[key]
+	return bz, ok, ok
+}
+
+// graphqlString quotes a string literal for a GraphQL query, which escapes
+// strings like JSON
+func graphqlString(s string) string {
+	bz, _ := json.Marshal(s)
+	return string(bz)
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGraphQLString(t *testing.T) {
+	if got, want := graphqlString(`master:a"b\c/chain.json`), `"master:a\"b\\c/chain.json"`; got != want {
+		t.Errorf("graphqlString = %s, want %s", got, want)
+	}
+}
+
+func TestBlobsLoad(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/graphql" || req.Header.Get("Authorization") != "Bearer token" {
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var body struct {
+			Query string `json:"query"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		query = body.Query
+		// osmosis has both files, cosmoshub has no asset list and the
+		// chain.json of cosmoshub is too large to be returned
+		_, _ = res.Write([]byte(`{"data":{"repository":{
+			"f0_0":{"text":"{\"chain_name\":\"osmosis\"}","isTruncated":false},
+			"f0_1":{"text":"{\"assets\":[]}","isTruncated":false},
+			"f1_0":{"text":null,"isTruncated":true},
+			"f1_1":null}}}`))
+	}))
+	defer srv.Close()
+
+	g := githubSource{repo: "cosmos/chain-registry", branch: "master", apiUrl: srv.URL, token: "token", quota: &quota{}, client: srv.Client()}
+	b := newBlobs()
+	if err := b.load(context.Background(), g, []string{"osmosis", "cosmoshub"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`repository(owner: "cosmos", name: "chain-registry")`,
+		`f0_0: object(expression: "master:osmosis/chain.json")`,
+		`f1_1: object(expression: "master:cosmoshub/assetlist.json")`,
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query %q doesn't contain %q", query, want)
+		}
+	}
+
+	tests := []struct {
+		chain, file string
+		body        string
+		found, ok   bool
+	}{
+		{"osmosis", "chain.json", `{"chain_name":"osmosis"}`, true, true},
+		{"osmosis", "assetlist.json", `{"assets":[]}`, true, true},
+		// truncated blobs are fetched from the raw host instead
+		{"cosmoshub", "chain.json", "", false, false},
+		{"cosmoshub", "assetlist.json", "", false, true},
+	}
+	for _, tc := range tests {
+		bz, found, ok := b.file(tc.chain, tc.file)
+		if string(bz) != tc.body || found != tc.found || ok != tc.ok {
+			t.Errorf("%s/%s: got %q %v %v, want %q %v %v", tc.chain, tc.file, bz, found, ok, tc.body, tc.found, tc.ok)
+		}
+	}
+}
+
+func TestBlobsLoadErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		_, _ = res.Write([]byte(`{"data":{"repository":null},"errors":[{"message":"Could not resolve to a Repository"}]}`))
+	}))
+	defer srv.Close()
+
+	g := githubSource{repo: "cosmos/missing", branch: "master", apiUrl: srv.URL, quota: &quota{}, client: srv.Client()}
+	err := newBlobs().load(context.Background(), g, []string{"osmosis"})
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("got error %v, want the graphql error", err)
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -15,38 +19,216 @@ import (
 // of the chain-registry which can be updated using `Pull`. It handles requests
 // for this data through the router.
 type Handler struct {
-	registryUrl  string
+	registryUrl string
+	apiUrl      string // base url of the github api
+	rawUrl      string // base url for raw github content
+	branch      string // or the commit SHA or tag if pinned
+	pinned      bool
+	source      Source // overrides the github repo when set
+	archive     bool   // pull the github repo as a tarball
+	graphql     bool   // pull the github repo through the GraphQL API
+	githubToken string
+	filter      chainFilter // chains that are pulled and served
+	refilter    bool        // the filter changed since the last pull
+	aliases     map[string][]string
+	prevIDs     map[string][]string // configured previous chain ids by chain name
+	indexFuncs  []customIndex       // see AddIndex
+	log         *log.Logger
+	client      *http.Client // used to pull the registry
+	reload      func() error // reloads the settings of the server
+
+	// pullMtx guards the pull in progress, if any
+	pullMtx sync.Mutex
+	pulling *pullCall
+
+	// mtx guards the registry state below
+	mtx          sync.RWMutex
 	lastUpdated  time.Time
+	commit       string // latest commit of the registry, if known
 	chains       []string
 	assets       []string
-	chainByAsset map[string]string // asset name -> chain name
+	chainByAsset map[string]string // asset name -> chain name of the preferred asset
 	chainById    map[string]string // chain id -> chain name
+	chainByKey   map[string]string // lower case chain name, id or alias -> chain name
+	assetByKey   map[string]string // lower case asset name or alias -> asset name
+	chainByNet   map[string]string // lower case chain id without revision -> chain name
+	chainAliases map[string][]string
+	assetAliases map[string][]string
+	assetChains  map[string][]string // asset name -> chain names, preferred first
 	chainList    map[string]types.Chain
 	assetList    map[string]types.AssetList
-	log          *log.Logger
+	// chainByPrevID maps a lower case previous chain id to the chain name and
+	// previousIDs the chain name to its previous chain ids
+	chainByPrevID map[string]string
+	previousIDs   map[string][]string
+	// files holds each file of the registry exactly as pulled, keyed by its
+	// path in the registry, e.g. osmosis/chain.json, and fileHashes their
+	// SHA-256. Whole chains and asset lists are served from it, which keeps
+	// the fields the types don't know about. A registry restored from a
+	// snapshot has none.
+	files      map[string][]byte
+	fileHashes map[string]string
+	// indexes holds the value of each custom index by its name
+	indexes map[string]interface{}
+
+	// changes made by each pull. Once old changes are dropped, changesFrom
+	// is the time from which the history is complete.
+	changes     []change
+	changesFrom time.Time
+	history     map[string]types.ChainHistory // chain name -> recent versions
+	tombstones  map[string]types.Tombstone    // chain name -> removal
+	notifiers   []Notifier
+	// optNotifiers are the notifiers given by the options of the server,
+	// which Reload replaces, unlike those added with AddNotifier
+	optNotifiers []Notifier
+
+	// cache holds encoded responses of the current registry state
+	cache *responseCache
+	// images holds the chain and asset logos fetched from the registry
+	images *imageCache
+	// imageChecks holds whether each logo resolves, if images are checked
+	imageChecks *imageChecks
+	// prices holds the prices of the assets, if a price provider is set
+	prices *priceCache
+	// supplies holds the recently queried supplies of the assets
+	supplies *supplyCache
+	// genesis holds the downloaded genesis files of the chains
+	genesis *genesisCache
+	// mirror holds the complete tree of the registry, if it is mirrored
+	mirror *mirror
+	// previews holds the registry at other git refs, see CreatePreview
+	previews *previews
+	// missing holds the chain files that pulls found missing
+	missing *missingFiles
+
+	// outcome of the most recent pull. If it failed, the registry from the
+	// last successful pull continues to be served.
+	lastAttempt      time.Time
+	lastPullDuration time.Duration
+	lastErr          error
+	chainErrors      map[string]string // chain name -> error of the last pull
+	schemas          *schemaReport
+	maxStaleness     time.Duration
+	awaitingPull     bool // the server was started before the initial pull
+	nextPull         func() time.Time
+	quota            *quota // github rate limit
+	fetches          *fetchMetrics
+	maintenance      types.Maintenance
+	audit            *auditLog // admin actions
+	usage            *usageCounter
 }
 
+const (
+	// DefaultBranch is the branch of the registry that is served by default
+	DefaultBranch = "master"
+
+	defaultApiUrl = "https://api.github.com"
+	defaultRawUrl = "https://raw.githubusercontent.com"
+)
+
 func NewHandler(registryUrl string, log *log.Logger) *Handler {
 	return &Handler{
 		registryUrl:  registryUrl,
+		apiUrl:       defaultApiUrl,
+		rawUrl:       defaultRawUrl,
+		branch:       DefaultBranch,
 		lastUpdated:  time.Unix(0, 0),
 		chains:       make([]string, 0),
 		assets:       make([]string, 0),
 		chainByAsset: make(map[string]string),
+		assetChains:  make(map[string][]string),
 		chainById:    make(map[string]string),
+		chainByKey:   make(map[string]string),
+		assetByKey:   make(map[string]string),
+		chainByNet:   make(map[string]string),
+		chainAliases: make(map[string][]string),
+		assetAliases: make(map[string][]string),
 		chainList:    make(map[string]types.Chain),
 		assetList:    make(map[string]types.AssetList),
+		history:      make(map[string]types.ChainHistory),
+		tombstones:   make(map[string]types.Tombstone),
+		cache:        newResponseCache(),
+		images:       newImageCache(),
+		imageChecks:  newImageChecks(),
+		prices:       &priceCache{},
+		supplies:     newSupplyCache(defaultHTTPClient),
+		genesis:      newGenesisCache("", defaultHTTPClient),
+		quota:        &quota{},
+		fetches:      newFetchMetrics(),
+		schemas:      newSchemaReport(),
+		audit:        newAuditLog(),
+		usage:        newUsageCounter(),
+		previews:     newPreviews(),
+		missing:      newMissingFiles(),
 		log:          log,
+		client:       defaultHTTPClient,
 	}
 }
 
-func (h Handler) Chains(res http.ResponseWriter, req *http.Request) {
-	respondWithJSON(res, h.chains)
+// SetHTTPClient sets the client used to pull the registry and to fetch logos,
+// genesis files and supplies. It defaults to a client shared by skychart,
+// apart from logos which are fetched with a 30 second timeout.
+func (h *Handler) SetHTTPClient(client *http.Client) {
+	h.client = client
+	h.images.client = client
+	h.supplies.client = client
+	h.genesis.client = client
+}
+
+// SetUpstream overrides the base urls used to pull the registry from. They
+// default to github's api and raw content hosts.
+func (h *Handler) SetUpstream(apiUrl, rawUrl string) {
+	h.apiUrl = apiUrl
+	h.rawUrl = rawUrl
+}
+
+// SetBranch sets the branch of the registry to pull from
+func (h *Handler) SetBranch(branch string) {
+	h.branch = branch
+}
+
+// Pin serves the registry as of the commit SHA or tag. A pinned registry
+// never goes stale.
+func (h *Handler) Pin(ref string) {
+	h.branch = ref
+	h.pinned = true
+}
+
+func (h *Handler) Chains(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	key, desc, ok := parseSort(req)
+	if !ok {
+		badRequest(res)
+		return
+	}
+	prefix := req.URL.Query().Get("chain_id_prefix")
+	if key == "" && prefix == "" {
+		h.respond(res, req, h.chains)
+		return
+	}
+	// sorted and filtered lists depend on the query so they aren't cached
+	chains := h.chains
+	if key != "" {
+		chains, ok = h.sortedChains(key, desc)
+		if !ok {
+			badRequest(res)
+			return
+		}
+	}
+	if prefix != "" {
+		chains = h.chainsWithIDPrefix(chains, prefix)
+	}
+	respondWithJSON(res, chains)
 }
 
 // Chain searches for a chain by either name or ID and
 // returns it if it exists
-func (h Handler) Chain(res http.ResponseWriter, req *http.Request) {
+func (h *Handler) Chain(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
 	vars := mux.Vars(req)
 	chainName, ok := vars["chain"]
 	if !ok {
@@ -54,15 +236,19 @@ func (h Handler) Chain(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	exists, chain := h.findChain(chainName)
-	if !exists {
+	name, ok := h.resolveChain(chainName)
+	chain, exists := h.chainList[name]
+	if !ok || !exists {
 		resourceNotFound(res)
 		return
 	}
-	respondWithJSON(res, chain)
+	h.respond(res, req, rawJSON{value: chain, json: h.files[name+"/chain.json"]})
 }
 
-func (h Handler) Endpoints(res http.ResponseWriter, req *http.Request) {
+func (h *Handler) Endpoints(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
 	vars := mux.Vars(req)
 	chainName, ok := vars["chain"]
 	if !ok {
@@ -80,103 +266,518 @@ func (h Handler) Endpoints(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// chains don't have to list any apis or peers
+	var apis types.Apis
+	if chain.Apis != nil {
+		apis = *chain.Apis
+	}
+	var peers types.Peers
+	if chain.Peers != nil {
+		peers = *chain.Peers
+	}
+	var endpoints interface{}
 	switch endpointType {
 	case "rpc":
-		respondWithJSON(res, chain.Apis.RPC)
+		endpoints = apis.RPC
 	case "grpc":
-		respondWithJSON(res, chain.Apis.Grpc)
+		endpoints = apis.Grpc
 	case "rest":
-		respondWithJSON(res, chain.Apis.REST)
+		endpoints = apis.REST
 	case "peers":
-		respondWithJSON(res, chain.Peers.PersistentPeers)
+		endpoints = peers.PersistentPeers
 	case "seeds":
-		respondWithJSON(res, chain.Peers.Seeds)
+		endpoints = peers.Seeds
+	default:
+		badRequest(res)
+		return
+	}
+	// filtered lists depend on the query so they aren't cached
+	if keep, ok := providerFilter(req); ok {
+		respondWithJSON(res, filterEndpoints(endpoints, keep))
+		return
+	}
+	h.respond(res, req, endpoints)
+}
+
+func (h *Handler) ChainAsset(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	chainName, ok = h.resolveChain(chainName)
+	if !ok {
+		badRequest(res)
+		return
+	}
+	assets := h.assetList[chainName]
+	filter, filtered := assetTypeFilter(req)
+	if filtered {
+		assets = filterAssetList(assets, filter)
+	}
+	if req.URL.Query().Get("format") == "csv" {
+		respondWithText(res, "text/csv", assetsCSV(map[string]types.AssetList{chainName: assets}))
+		return
+	}
+	// prices change independently of the registry and filtered lists depend
+	// on the query so neither are cached
+	if includePrice(req) {
+		respondWithJSON(res, h.pricedAssetList(assets))
+		return
+	}
+	if filtered {
+		respondWithJSON(res, assets)
+		return
+	}
+	h.respond(res, req, rawJSON{value: assets, json: h.files[chainName+"/assetlist.json"]})
+}
+
+// Keplr returns the chain in the ChainInfo format expected by Keplr's
+// experimentalSuggestChain
+func (h *Handler) Keplr(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	exists, chain := h.findChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	_, assetList := h.findAssetList(chainName)
+	respondWithJSON(res, keplrChainInfo(chain, assetList))
+}
+
+// Wallet returns the chain in the configuration format expected by the
+// requested wallet provider
+func (h *Handler) Wallet(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	provider, ok := vars["provider"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	exists, chain := h.findChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	_, assetList := h.findAssetList(chainName)
+
+	switch provider {
+	case "keplr", "leap":
+		// Leap accepts the same chain info as Keplr
+		respondWithJSON(res, keplrChainInfo(chain, assetList))
+	case "cosmostation":
+		respondWithJSON(res, cosmostationChain(chain, assetList))
 	default:
 		badRequest(res)
 	}
 }
 
-func (h Handler) ChainAsset(res http.ResponseWriter, req *http.Request) {
+// HermesConfig returns a Hermes config.toml fragment for the comma separated
+// list of chains in the "chains" query parameter
+func (h *Handler) HermesConfig(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	names := req.URL.Query().Get("chains")
+	if names == "" {
+		badRequest(res)
+		return
+	}
+	chains := make([]types.Chain, 0)
+	for _, name := range strings.Split(names, ",") {
+		exists, chain := h.findChain(strings.TrimSpace(name))
+		if !exists {
+			resourceNotFound(res)
+			return
+		}
+		chains = append(chains, chain)
+	}
+	respondWithText(res, "application/toml", hermesConfig(chains))
+}
+
+// RlyChain returns the chain as a go relayer chain file
+func (h *Handler) RlyChain(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
 	vars := mux.Vars(req)
 	chainName, ok := vars["chain"]
 	if !ok {
 		badRequest(res)
 		return
 	}
-	assets, ok := h.assetList[chainName]
+	exists, chain := h.findChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	respondWithJSON(res, rlyChain(chain))
+}
+
+func (h *Handler) Assets(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	filter, filtered := assetTypeFilter(req)
+	if req.URL.Query().Get("format") == "csv" {
+		assetLists := h.assetList
+		if filtered {
+			assetLists = filterAssetLists(assetLists, filter)
+		}
+		respondWithText(res, "text/csv", assetsCSV(assetLists))
+		return
+	}
+	key, desc, ok := parseSort(req)
 	if !ok {
-		chainName, ok = h.chainById[chainName]
+		badRequest(res)
+		return
+	}
+	if key == "" && !filtered {
+		h.respond(res, req, h.assets)
+		return
+	}
+	// sorted and filtered lists depend on the query so they aren't cached
+	assets := h.assets
+	if key != "" {
+		assets, ok = h.sortedAssets(key, desc)
 		if !ok {
 			badRequest(res)
+			return
 		}
-		assets = h.assetList[chainName]
+	}
+	if filtered {
+		assets = h.assetsOfType(assets, filter)
 	}
 	respondWithJSON(res, assets)
 }
 
-func (h Handler) Assets(res http.ResponseWriter, req *http.Request) {
-	respondWithJSON(res, h.assets)
+// AllAssets returns every asset of every chain, each annotated with the name
+// and id of its chain, ordered by chain name
+func (h *Handler) AllAssets(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	names := make([]string, 0, len(h.assetList))
+	for name := range h.assetList {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	filter, filtered := assetTypeFilter(req)
+	assets := make([]types.ChainAssetElement, 0, len(h.assets))
+	for _, name := range names {
+		assetList := h.assetList[name]
+		for _, asset := range assetList.Assets {
+			if filtered && !filter[assetType(asset)] {
+				continue
+			}
+			assets = append(assets, types.ChainAssetElement{
+				Chain:        name,
+				ChainID:      assetList.ChainID,
+				AssetElement: asset,
+			})
+		}
+	}
+	// filtered lists depend on the query so they aren't cached
+	if filtered {
+		respondWithJSON(res, assets)
+		return
+	}
+	h.respond(res, req, assets)
 }
 
-func (h Handler) Asset(res http.ResponseWriter, req *http.Request) {
+func (h *Handler) Asset(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
 	vars := mux.Vars(req)
 	assetName, ok := vars["asset"]
 	if !ok {
 		badRequest(res)
 		return
 	}
-	chainName, ok := h.chainByAsset[assetName]
+	asset, ok := h.findAsset(assetName)
 	if !ok {
 		resourceNotFound(res)
 		return
 	}
+	h.respondWithAsset(res, req, h.chainByAsset[asset.Display], asset)
+}
 
-	assetList := h.assetList[chainName]
-	for _, asset := range assetList.Assets {
-		if asset.Display == assetName {
-			respondWithJSON(res, asset)
-			return
-		}
+// respondWithAsset responds with the asset of the chain, along with its price
+// and IBC-bridged variants if the request includes them. The caller must hold
+// the read lock.
+func (h *Handler) respondWithAsset(res http.ResponseWriter, req *http.Request, chain string, asset types.AssetElement) {
+	// prices and bridged variants aren't cached
+	price, bridged := includes(req, "price"), includes(req, "bridged")
+	if !price && !bridged {
+		h.respond(res, req, asset)
+		return
 	}
+	priced := types.PricedAsset{AssetElement: asset}
+	if price {
+		priced = h.pricedAsset(asset)
+	}
+	if !bridged {
+		respondWithJSON(res, priced)
+		return
+	}
+	respondWithJSON(res, types.BridgedAsset{PricedAsset: priced, Bridged: h.bridgedAssets(chain, asset)})
+}
 
-	resourceNotFound(res)
+// AssetMatches returns every asset with the display name, or an alias of it,
+// along with the chain it is registered on. The asset native to a chain comes
+// first. It replaces Asset in v2 of the API.
+func (h *Handler) AssetMatches(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	assets := h.findAssets(mux.Vars(req)["asset"])
+	if len(assets) == 0 {
+		resourceNotFound(res)
+		return
+	}
+	h.respond(res, req, assets)
 }
 
-func (h Handler) findChain(name string) (bool, types.Chain) {
-	chain, ok := h.chainList[name]
-	if ok {
-		return true, chain
+// ChainAssetByName returns the asset of a chain with the display name, or an
+// alias of it, which is unique unlike the display name alone
+func (h *Handler) ChainAssetByName(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := h.resolveChain(vars["chain"])
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	display, ok := h.resolveAsset(vars["asset"])
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	asset, ok := h.chainAsset(chainName, display)
+	if !ok {
+		resourceNotFound(res)
+		return
 	}
+	h.respondWithAsset(res, req, chainName, asset)
+}
 
-	name, ok = h.chainById[name]
+func (h *Handler) findChain(name string) (bool, types.Chain) {
+	name, ok := h.resolveChain(name)
 	if !ok {
 		return false, types.Chain{}
 	}
+	chain, ok := h.chainList[name]
+	return ok, chain
+}
 
-	return true, h.chainList[name]
+// findAsset returns an asset by its display name
+func (h *Handler) findAsset(name string) (types.AssetElement, bool) {
+	name, ok := h.resolveAsset(name)
+	if !ok {
+		return types.AssetElement{}, false
+	}
+	return h.chainAsset(h.chainByAsset[name], name)
 }
 
-func respondWithJSON(w http.ResponseWriter, payload interface{}) {
-	response, _ := json.Marshal(payload)
+// findAssets returns every asset with the name, or an alias of it, along with
+// its chain. The preferred asset comes first.
+func (h *Handler) findAssets(name string) []types.ChainAssetElement {
+	name, ok := h.resolveAsset(name)
+	if !ok {
+		return nil
+	}
+	assets := make([]types.ChainAssetElement, 0, len(h.assetChains[name]))
+	for _, chain := range h.assetChains[name] {
+		if asset, ok := h.chainAsset(chain, name); ok {
+			assets = append(assets, types.ChainAssetElement{
+				Chain:        chain,
+				ChainID:      h.assetList[chain].ChainID,
+				AssetElement: asset,
+			})
+		}
+	}
+	return assets
+}
+
+// chainAsset returns the asset of the chain with the display name
+func (h *Handler) chainAsset(chain, display string) (types.AssetElement, bool) {
+	for _, asset := range h.assetList[chain].Assets {
+		if asset.Display == display {
+			return asset, true
+		}
+	}
+	return types.AssetElement{}, false
+}
+
+// isNative returns true if the chain's asset with the display name wasn't
+// transferred from another chain over IBC
+func (h *Handler) isNative(chain, display string) bool {
+	asset, ok := h.chainAsset(chain, display)
+	return ok && asset.Ibc == nil
+}
+
+// resolveChain returns the registry name of a chain given either its name, ID
+// or an alias. Names and IDs are matched case-insensitively if there is no
+// exact match.
+func (h *Handler) resolveChain(name string) (string, bool) {
+	if _, ok := h.chainList[name]; ok {
+		return name, true
+	}
+	if _, ok := h.assetList[name]; ok {
+		return name, true
+	}
+	if resolved, ok := h.chainById[name]; ok {
+		return resolved, true
+	}
+	if resolved, ok := h.chainByKey[strings.ToLower(name)]; ok {
+		return resolved, true
+	}
+	// chain ids change with each revision, e.g. from osmosis-1 to osmosis-2,
+	// so previous ids and earlier revisions resolve to the chain, while later
+	// revisions that aren't registered yet don't
+	if resolved, ok := h.previousChainID(name); ok {
+		return resolved, true
+	}
+	return "", false
+}
+
+// resolveAsset returns the display name of an asset given its display name or
+// an alias, matching it case-insensitively if there is no exact match
+func (h *Handler) resolveAsset(name string) (string, bool) {
+	if _, ok := h.chainByAsset[name]; ok {
+		return name, true
+	}
+	resolved, ok := h.assetByKey[strings.ToLower(name)]
+	return resolved, ok
+}
+
+func (h *Handler) findAssetList(name string) (bool, types.AssetList) {
+	name, ok := h.resolveChain(name)
+	if !ok {
+		return false, types.AssetList{}
+	}
+	assetList, ok := h.assetList[name]
+	return ok, assetList
+}
+
+// respond encodes the payload as protobuf if the client accepts it and the
+// payload supports it, otherwise it falls back to JSON. Encoded responses are
+// cached until the registry is next updated, and clients can cache them until
+// the registry's last update.
+func (h *Handler) respond(w http.ResponseWriter, req *http.Request, payload interface{}) {
+	if h.notModified(w, req) {
+		return
+	}
+	acceptsProto := strings.Contains(req.Header.Get("Accept"), protobufContentType)
+	// none of the cached responses depend on the query so it is left out of
+	// the key, which keeps arbitrary query strings from growing the cache
+	key := req.URL.Path
+	if acceptsProto {
+		key = protobufContentType + " " + key
+	}
+	if cached, ok := h.cache.get(key); ok {
+		respondWithBytes(w, cached.contentType, cached.body)
+		return
+	}
+
+	response := cachedResponse{contentType: "application/json"}
+	raw, isRaw := payload.(rawJSON)
+	if isRaw {
+		payload = raw.value
+	}
+	if acceptsProto {
+		if bz, ok := marshalProto(payload); ok {
+			response = cachedResponse{contentType: protobufContentType, body: bz}
+		}
+	}
+	if response.body == nil && isRaw {
+		response.body = raw.json
+	}
+	if response.body == nil {
+		response.body, _ = json.Marshal(payload)
+	}
+	h.cache.set(key, response)
+	respondWithBytes(w, response.contentType, response.body)
+}
 
+// rawJSON is a payload that is served as the JSON it was pulled as, if any,
+// rather than encoding its value, so that the fields the types don't know about
+// are kept. Protobuf is still encoded from the value.
+type rawJSON struct {
+	value interface{}
+	json  []byte
+}
+
+// setCORSHeaders lets browsers on any origin read the response
+func setCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET")
 	w.Header().Set("Access-Control-Allow-Headers", "Origin, Accept, Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+}
+
+func respondWithJSON(w http.ResponseWriter, payload interface{}) {
+	response, _ := json.Marshal(payload)
+
+	setCORSHeaders(w)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(response)
 }
 
+func respondWithBytes(w http.ResponseWriter, contentType string, response []byte) {
+	setCORSHeaders(w)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(response)
+}
+
+func respondWithText(w http.ResponseWriter, contentType, payload string) {
+	setCORSHeaders(w)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(payload))
+}
+
 func resourceNotFound(w http.ResponseWriter) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET")
-	w.Header().Set("Access-Control-Allow-Headers", "Origin, Accept, Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+	setCORSHeaders(w)
 	w.WriteHeader(http.StatusNotFound)
 }
 
 func badRequest(w http.ResponseWriter) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET")
-	w.Header().Set("Access-Control-Allow-Headers", "Origin, Accept, Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+	setCORSHeaders(w)
 	w.WriteHeader(http.StatusBadRequest)
 }
+
+func badGateway(w http.ResponseWriter) {
+	setCORSHeaders(w)
+	w.WriteHeader(http.StatusBadGateway)
+}
+
+func internalError(w http.ResponseWriter) {
+	setCORSHeaders(w)
+	w.WriteHeader(http.StatusInternalServerError)
+}
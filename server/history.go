@@ -0,0 +1,67 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// maxVersions bounds the number of versions kept for each chain and asset list
+const maxVersions = 10
+
+// recordHistory appends the current version of every chain and asset list that
+// differs from the one before the update. The caller must hold the write lock.
+func (h *Handler) recordHistory(chains map[string]types.Chain, assetLists map[string]types.AssetList, now time.Time) {
+	for name, chain := range h.chainList {
+		if prev, ok := chains[name]; ok && reflect.DeepEqual(prev, chain) {
+			continue
+		}
+		history := h.history[name]
+		history.Chain = append(history.Chain, types.ChainVersion{Timestamp: now, Chain: chain})
+		if len(history.Chain) > maxVersions {
+			history.Chain = history.Chain[len(history.Chain)-maxVersions:]
+		}
+		h.history[name] = history
+	}
+	for name, assetList := range h.assetList {
+		if prev, ok := assetLists[name]; ok && reflect.DeepEqual(prev, assetList) {
+			continue
+		}
+		history := h.history[name]
+		history.AssetList = append(history.AssetList, types.AssetListVersion{Timestamp: now, AssetList: assetList})
+		if len(history.AssetList) > maxVersions {
+			history.AssetList = history.AssetList[len(history.AssetList)-maxVersions:]
+		}
+		h.history[name] = history
+	}
+}
+
+// History returns the recent versions of a chain's chain.json and assetlist.json
+func (h *Handler) History(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+	name, exists := h.resolveChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	history := h.history[name]
+	if history.Chain == nil {
+		history.Chain = []types.ChainVersion{}
+	}
+	if history.AssetList == nil {
+		history.AssetList = []types.AssetListVersion{}
+	}
+	respondWithJSON(res, history)
+}
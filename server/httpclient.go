@@ -0,0 +1,97 @@
+package server
+
+import (
+	"net/http"
+)
+
+// DefaultUserAgent identifies the requests skychart makes upstream
+const DefaultUserAgent = "skychart"
+
+// defaultHTTPClient is shared by every upstream request that isn't given a
+// client, so that connections are reused across them
+var defaultHTTPClient = NewHTTPClient(DefaultUserAgent)
+
+// NewHTTPClient returns a client that pools connections per host, negotiates
+// HTTP/2, honours the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables and sets the user agent of requests that don't have one.
+func NewHTTPClient(userAgent string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	transport.ForceAttemptHTTP2 = true
+	// a pull makes many requests to the same host
+	transport.MaxIdleConnsPerHost = 16
+	return &http.Client{Transport: userAgentTransport{userAgent: userAgent, next: transport}}
+}
+
+// httpClient returns the client, or the shared client if it's nil
+func httpClient(client *http.Client) *http.Client {
+	if client == nil {
+		return defaultHTTPClient
+	}
+	return client
+}
+
+// userAgentTransport sets the User-Agent header of requests without one
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" && t.userAgent != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// useHTTPClient sets the client of the source, price provider, notifiers,
+// primary and ACME config that weren't given one
+func (o *options) useHTTPClient(client *http.Client) {
+	switch source := o.source.(type) {
+	case GitLabSource:
+		if source.Client == nil {
+			source.Client = client
+			o.source = source
+		}
+	case GiteaSource:
+		if source.Client == nil {
+			source.Client = client
+			o.source = source
+		}
+	case S3Source:
+		if source.Client == nil {
+			source.Client = client
+			o.source = source
+		}
+	}
+	if prices, ok := o.prices.(CoinGecko); ok && prices.Client == nil {
+		prices.Client = client
+		o.prices = prices
+	}
+	for i, notifier := range o.notifiers {
+		switch n := notifier.(type) {
+		case Webhook:
+			if n.Client == nil {
+				n.Client = client
+				o.notifiers[i] = n
+			}
+		case Slack:
+			if n.Client == nil {
+				n.Client = client
+				o.notifiers[i] = n
+			}
+		case Discord:
+			if n.Client == nil {
+				n.Client = client
+				o.notifiers[i] = n
+			}
+		}
+	}
+	if primary, ok := o.sharedStore.(*Primary); ok && primary.Client == nil {
+		primary.Client = client
+	}
+	if o.acme != nil && o.acme.Client == nil {
+		o.acme.Client = client
+	}
+}
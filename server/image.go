@@ -0,0 +1,336 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// registers the decoders of the other raster formats logos use
+	_ "image/gif"
+	_ "image/jpeg"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// imageMaxAge is how long clients may cache a logo
+	imageMaxAge = 7 * 24 * time.Hour
+	// maxImageBytes bounds the size of a logo fetched from upstream
+	maxImageBytes = 5 << 20
+	// maxImageDimension bounds the size a logo can be resized to
+	maxImageDimension = 1024
+	// maxImages bounds the number of logos kept in the image cache
+	maxImages = 2000
+	// maxImagePixels bounds the size of a raster logo that is decoded to be
+	// resized, since a small compressed file can decode to gigabytes
+	maxImagePixels = 4096 * 4096
+	// untrustedContentPolicy keeps scripts in logos from running in the
+	// origin of the server when they are opened directly
+	untrustedContentPolicy = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+
+	svgContentType = "image/svg+xml"
+)
+
+// imageCache holds the logos fetched from the registry, keyed by their URL and
+// the size they were resized to. Unlike the response cache it isn't reset on
+// every update, since a logo only changes when its URL does.
+type imageCache struct {
+	mtx     sync.Mutex
+	entries map[string]cachedResponse
+	client  *http.Client
+}
+
+func newImageCache() *imageCache {
+	return &imageCache{
+		entries: make(map[string]cachedResponse),
+		client:  &http.Client{Transport: defaultHTTPClient.Transport, Timeout: 30 * time.Second},
+	}
+}
+
+// get returns the logo at url, fetching and caching it if needed. Raster
+// images are resized to fit within size pixels if size is not zero, reusing
+// the cached original.
+func (c *imageCache) get(ctx context.Context, url string, size int) (cachedResponse, error) {
+	key := url + " " + strconv.Itoa(size)
+	c.mtx.Lock()
+	logo, ok := c.entries[key]
+	c.mtx.Unlock()
+	if ok {
+		return logo, nil
+	}
+
+	var err error
+	if size == 0 {
+		logo, err = c.fetch(ctx, url)
+	} else {
+		logo, err = c.get(ctx, url, 0)
+		if err == nil && logo.contentType != svgContentType {
+			var body []byte
+			body, err = resizeImage(logo.body, size)
+			logo = cachedResponse{contentType: "image/png", body: body}
+		}
+	}
+	if err != nil {
+		return logo, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if len(c.entries) >= maxImages {
+		c.entries = make(map[string]cachedResponse)
+	}
+	c.entries[key] = logo
+	return logo, nil
+}
+
+func (c *imageCache) fetch(ctx context.Context, url string) (cachedResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return cachedResponse{}, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return cachedResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return cachedResponse{}, fmt.Errorf("unexpected status code for image %s: %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return cachedResponse{}, err
+	}
+	if len(body) > maxImageBytes {
+		return cachedResponse{}, fmt.Errorf("image %s exceeds %d bytes", url, maxImageBytes)
+	}
+	return cachedResponse{contentType: imageContentType(url, body), body: body}, nil
+}
+
+// imageContentType detects the type of an image from its content. Raw hosts
+// such as github serve every file as text/plain, so the upstream header can't
+// be relied on. SVGs are text and are recognised by their extension.
+func imageContentType(url string, body []byte) string {
+	if strings.EqualFold(path.Ext(url), ".svg") {
+		return svgContentType
+	}
+	return http.DetectContentType(body)
+}
+
+// resizeImage scales a raster image down to fit within size pixels, keeping
+// its aspect ratio, and encodes it as PNG. Images are never scaled up.
+func resizeImage(body []byte, size int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("image of %dx%d pixels is too large to resize", config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, height*size/width
+		} else {
+			width, height = width*size/height, size
+		}
+		if width < 1 {
+			width = 1
+		}
+		if height < 1 {
+			height = 1
+		}
+		src = scale(src, width, height)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scale downsamples src to width x height by averaging the source pixels that
+// fall within each destination pixel
+func scale(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			// the sums are alpha-premultiplied
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// ChainImage serves the logo of a chain
+func (h *Handler) ChainImage(res http.ResponseWriter, req *http.Request) {
+	// the lock is released before the logo is fetched
+	h.mtx.RLock()
+	exists, chain := h.findChain(mux.Vars(req)["chain"])
+	h.mtx.RUnlock()
+
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	h.serveImage(res, req, chain.LogoURIs)
+}
+
+// AssetImage serves the logo of an asset
+func (h *Handler) AssetImage(res http.ResponseWriter, req *http.Request) {
+	// the lock is released before the logo is fetched
+	h.mtx.RLock()
+	asset, exists := h.findAsset(mux.Vars(req)["asset"])
+	h.mtx.RUnlock()
+
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	h.serveImage(res, req, asset.LogoURIs)
+}
+
+// setUntrustedContentHeaders keeps content fetched from the registry's
+// sources, such as SVG logos, from running scripts or being sniffed as
+// another type when it is served from the server's origin
+func setUntrustedContentHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Security-Policy", untrustedContentPolicy)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+}
+
+// serveImage serves one of the logos, preferring the PNG unless the "format"
+// query parameter is "svg". Raster logos are resized to fit within the "size"
+// query parameter, if given.
+func (h *Handler) serveImage(res http.ResponseWriter, req *http.Request, logos *types.LogoURIs) {
+	query := req.URL.Query()
+	size := 0
+	if s := query.Get("size"); s != "" {
+		var err error
+		size, err = strconv.Atoi(s)
+		if err != nil || size < 1 || size > maxImageDimension {
+			badRequest(res)
+			return
+		}
+	}
+
+	if logos == nil {
+		resourceNotFound(res)
+		return
+	}
+	candidates := []*string{logos.PNG, logos.SVG}
+	if query.Get("format") == "svg" {
+		candidates = []*string{logos.SVG, logos.PNG}
+	}
+	url := ""
+	for _, candidate := range candidates {
+		if candidate != nil && *candidate != "" {
+			url = *candidate
+			break
+		}
+	}
+	if url == "" {
+		resourceNotFound(res)
+		return
+	}
+
+	logo, err := h.images.get(req.Context(), url, size)
+	if err != nil {
+		h.log.Printf("fetching image %s: %v", url, err)
+		badGateway(res)
+		return
+	}
+	res.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(imageMaxAge.Seconds())))
+	setUntrustedContentHeaders(res)
+	respondWithBytes(res, logo.contentType, logo.body)
+}
+
+// imageList returns every logo listed by the chains and their assets, by chain
+// name, the chain's logo first and then its assets in the order of its asset
+// list. The caller must hold the read lock.
+func (h *Handler) imageList() []types.Image {
+	images := make([]types.Image, 0)
+	for _, name := range h.chains {
+		images = appendImages(images, name, "", h.chainList[name].LogoURIs)
+		for _, asset := range h.assetList[name].Assets {
+			images = appendImages(images, name, asset.Base, asset.LogoURIs)
+		}
+	}
+	return images
+}
+
+func appendImages(images []types.Image, chain, asset string, logos *types.LogoURIs) []types.Image {
+	if logos == nil {
+		return images
+	}
+	for _, logo := range []struct {
+		format string
+		uri    *string
+	}{{"png", logos.PNG}, {"svg", logos.SVG}} {
+		if logo.uri != nil && *logo.uri != "" {
+			images = append(images, types.Image{Chain: chain, Asset: asset, Format: logo.format, URI: *logo.uri})
+		}
+	}
+	return images
+}
+
+// Images returns every chain and asset logo of the registry. If images are
+// checked, each has the outcome of its last check and ?broken=true only
+// returns those that didn't resolve.
+func (h *Handler) Images(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	images := h.imageList()
+	if !h.imageChecks.checking() {
+		h.respond(res, req, images)
+		return
+	}
+	brokenOnly := req.URL.Query().Get("broken") == "true"
+	checked := make([]types.Image, 0, len(images))
+	for _, image := range images {
+		if check, ok := h.imageChecks.get(image.URI); ok {
+			image.Check = &check
+		}
+		if brokenOnly && (image.Check == nil || !image.Check.Broken) {
+			continue
+		}
+		checked = append(checked, image)
+	}
+	// checks change independently of the registry so they aren't cached
+	respondWithJSON(res, checked)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+func TestServeSVGHeaders(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		_, _ = res.Write([]byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`))
+	}))
+	defer upstream.Close()
+	h := newTestHandler(upstream.URL)
+	h.images.client = upstream.Client()
+
+	svg := upstream.URL + "/osmo.svg"
+	rec := httptest.NewRecorder()
+	h.serveImage(rec, httptest.NewRequest(http.MethodGet, "/v1/chain/osmosis/image", nil), &types.LogoURIs{SVG: &svg})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Security-Policy"); got != untrustedContentPolicy {
+		t.Errorf("Content-Security-Policy %q, want %q", got, untrustedContentPolicy)
+	}
+	if got := rec.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("X-Content-Type-Options %q, want nosniff", got)
+	}
+}
+
+// TestResizeImageBomb checks that images that would decode to more than
+// maxImagePixels are refused from their header alone
+func TestResizeImageBomb(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	// rewrite the dimensions in the IHDR chunk, which follows the 8 byte
+	// signature and the chunk's length and type, and its checksum
+	bomb := buf.Bytes()
+	binary.BigEndian.PutUint32(bomb[16:], 50000)
+	binary.BigEndian.PutUint32(bomb[20:], 50000)
+	binary.BigEndian.PutUint32(bomb[29:], crc32.ChecksumIEEE(bomb[12:29]))
+	if _, err := resizeImage(bomb, 64); err == nil {
+		t.Error("image of 50000x50000 pixels was resized")
+	} else if !strings.Contains(err.Error(), "too large") {
+		t.Errorf("bomb refused for another reason: %v", err)
+	}
+
+	buf.Reset()
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 256, 128))); err != nil {
+		t.Fatal(err)
+	}
+	resized, err := resizeImage(buf.Bytes(), 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := png.DecodeConfig(bytes.NewReader(resized))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Width != 64 || config.Height != 32 {
+		t.Errorf("resized to %dx%d, want 64x32", config.Width, config.Height)
+	}
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// DefaultImageCheckInterval is how often every image is checked by
+	// default
+	DefaultImageCheckInterval = 24 * time.Hour
+
+	// imageCheckPoll is how often images added by a pull are looked for
+	// between checks of every image
+	imageCheckPoll = time.Minute
+	// imageCheckWorkers bounds the number of images fetched at once
+	imageCheckWorkers = 8
+)
+
+// imageChecks holds the outcome of the last check of each image, keyed by its
+// URL. It has its own lock so that checking images doesn't block reads of the
+// registry.
+type imageChecks struct {
+	mtx     sync.RWMutex
+	enabled bool
+	results map[string]types.ImageCheck
+}
+
+func newImageChecks() *imageChecks {
+	return &imageChecks{results: make(map[string]types.ImageCheck)}
+}
+
+// checking reports whether images are checked, see WithImageChecks
+func (c *imageChecks) checking() bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.enabled
+}
+
+func (c *imageChecks) get(url string) (types.ImageCheck, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	check, ok := c.results[url]
+	return check, ok
+}
+
+// checkImages checks that every image of the registry resolves every interval
+// until the context is cancelled. Images added by a pull in between are
+// checked within a minute.
+func (h *Handler) checkImages(ctx context.Context, interval time.Duration) {
+	h.imageChecks.mtx.Lock()
+	h.imageChecks.enabled = true
+	h.imageChecks.mtx.Unlock()
+
+	ticker := time.NewTicker(imageCheckPoll)
+	defer ticker.Stop()
+	var last time.Time
+	for {
+		all := time.Since(last) >= interval
+		if all {
+			last = time.Now()
+		}
+		h.refreshImageChecks(ctx, all)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshImageChecks checks the images that haven't been yet, or every image
+// if all is set. The outcomes of images no longer listed are dropped.
+func (h *Handler) refreshImageChecks(ctx context.Context, all bool) {
+	h.mtx.RLock()
+	images := h.imageList()
+	h.mtx.RUnlock()
+
+	listed := make(map[string]bool, len(images))
+	urls := make([]string, 0)
+	for _, image := range images {
+		if listed[image.URI] {
+			continue
+		}
+		listed[image.URI] = true
+		if _, checked := h.imageChecks.get(image.URI); all || !checked {
+			urls = append(urls, image.URI)
+		}
+	}
+
+	results := make([]types.ImageCheck, len(urls))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, imageCheckWorkers)
+	for i, url := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, url string) {
+			defer func() { <-sem; wg.Done() }()
+			results[i] = types.ImageCheck{Checked: time.Now()}
+			if err := h.checkImage(ctx, url); err != nil {
+				results[i].Broken, results[i].Error = true, err.Error()
+			}
+		}(i, url)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	c := h.imageChecks
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for url := range c.results {
+		if !listed[url] {
+			delete(c.results, url)
+		}
+	}
+	broken := 0
+	for i, url := range urls {
+		c.results[url] = results[i]
+		if results[i].Broken {
+			broken++
+		}
+	}
+	if broken > 0 {
+		h.log.Printf("checked %d images, %d broken", len(urls), broken)
+	}
+}
+
+// checkImage fetches the image at url, returning an error unless it is found
+func (h *Handler) checkImage(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.images.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// hashFiles returns the hex encoded SHA-256 of each file
+func hashFiles(files map[string][]byte) map[string]string {
+	hashes := make(map[string]string, len(files))
+	for path, file := range files {
+		sum := sha256.Sum256(file)
+		hashes[path] = hex.EncodeToString(sum[:])
+	}
+	return hashes
+}
+
+// Integrity returns the commit the registry was pulled at and the SHA-256 of
+// each file served under /raw and /schemas, so that clients can check the
+// files against the repo. A registry restored from a snapshot has no files
+// until the next pull.
+func (h *Handler) Integrity(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	hashes := h.fileHashes
+	if hashes == nil {
+		hashes = make(map[string]string)
+	}
+	h.respond(res, req, types.Integrity{
+		Commit:      h.commit,
+		LastUpdated: h.lastUpdated,
+		Files:       hashes,
+	})
+}
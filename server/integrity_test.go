@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// TestIntegrityAtCommit checks that the files are pulled at the commit that
+// /integrity reports along with their hashes, rather than at the head of the
+// branch, which may have moved on
+func TestIntegrityAtCommit(t *testing.T) {
+	for _, archive := range []bool{false, true} {
+		rs := newTestRegistry()
+		upstream, _ := url.Parse(rs.URL)
+		proxy := httputil.NewSingleHostReverseProxy(upstream)
+		var (
+			mtx       sync.Mutex
+			requested []string
+		)
+		ps := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			if strings.HasSuffix(req.URL.Path, ".json") || strings.Contains(req.URL.Path, "/tarball/") {
+				mtx.Lock()
+				requested = append(requested, req.URL.Path)
+				mtx.Unlock()
+			}
+			proxy.ServeHTTP(res, req)
+		}))
+		h := newTestHandler(ps.URL)
+		h.SetArchive(archive)
+
+		if err := h.Pull(context.Background()); err != nil {
+			t.Fatalf("archive %v: %v", archive, err)
+		}
+		if h.commit == "" {
+			t.Fatalf("archive %v: no commit was pulled", archive)
+		}
+		mtx.Lock()
+		for _, path := range requested {
+			if !strings.Contains(path, "/"+h.commit) {
+				t.Errorf("archive %v: %s wasn't requested at commit %s", archive, path, h.commit)
+			}
+		}
+		mtx.Unlock()
+
+		router := mux.NewRouter()
+		router.HandleFunc("/integrity", h.Integrity)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/integrity", nil))
+		var integrity types.Integrity
+		if err := json.Unmarshal(rec.Body.Bytes(), &integrity); err != nil {
+			t.Fatal(err)
+		}
+		if integrity.Commit != h.commit {
+			t.Errorf("archive %v: integrity commit %s, want %s", archive, integrity.Commit, h.commit)
+		}
+		sum := sha256.Sum256(h.files["osmosis/chain.json"])
+		if got := integrity.Files["osmosis/chain.json"]; got != hex.EncodeToString(sum[:]) {
+			t.Errorf("archive %v: osmosis/chain.json hashed as %q, want the hash of the file served", archive, got)
+		}
+		ps.Close()
+		rs.Close()
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// registrySchemas are the JSON schemas at the root of the registry that its
+// files are validated against
+var registrySchemas = []string{"chain.schema.json", "assetlist.schema.json", "ibc_data.schema.json"}
+
+// getSchemas adds the JSON schemas of the registry at the commit to the files,
+// so that they match the commit the files were pulled at, and returns those
+// that couldn't be fetched, which keep their previous version. Schemas the
+// registry doesn't have are left out.
+func (h *Handler) getSchemas(ctx context.Context, source Source, commit string, files map[string][]byte) []string {
+	root, ok := source.(rootSource)
+	if !ok {
+		return nil
+	}
+	var failed []string
+	for _, schema := range registrySchemas {
+		bz, found, err := root.RootFile(ctx, commit, schema)
+		if err != nil {
+			h.log.Printf("failed to fetch %s: %v", schema, err)
+			failed = append(failed, schema)
+			continue
+		}
+		if found {
+			files[schema] = bz
+		}
+	}
+	return failed
+}
+
+// Schemas returns the names of the JSON schemas of the registry that are
+// served under /schemas
+func (h *Handler) Schemas(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	schemas := []string{}
+	for _, schema := range registrySchemas {
+		if _, ok := h.files[schema]; ok {
+			schemas = append(schemas, schema)
+		}
+	}
+	h.respond(res, req, schemas)
+}
+
+// Schema serves a JSON schema of the registry as of the commit that is
+// served, given by the X-Registry-Commit header if known, so that validation
+// tooling checks files against the schema they were written for. A registry
+// restored from a snapshot has no schemas until the next pull.
+func (h *Handler) Schema(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	schema, ok := h.files[mux.Vars(req)["schema"]]
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	if h.commit != "" {
+		res.Header().Set("X-Registry-Commit", h.commit)
+	}
+	respondWithBytes(res, "application/schema+json", schema)
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestSchemasAtCommit checks that the schemas are fetched at the commit the
+// chains were pulled at rather than at the head of the branch
+func TestSchemasAtCommit(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	upstream, _ := url.Parse(rs.URL)
+	proxy := httputil.NewSingleHostReverseProxy(upstream)
+	var (
+		mtx       sync.Mutex
+		requested []string
+	)
+	ps := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, ".schema.json") {
+			mtx.Lock()
+			requested = append(requested, req.URL.Path)
+			mtx.Unlock()
+		}
+		proxy.ServeHTTP(res, req)
+	}))
+	defer ps.Close()
+	h := newTestHandler(ps.URL)
+
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if h.commit == "" {
+		t.Fatal("no commit was pulled")
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	if len(requested) != len(registrySchemas) {
+		t.Fatalf("requested schemas %v", requested)
+	}
+	for _, path := range requested {
+		if !strings.Contains(path, "/"+h.commit+"/") {
+			t.Errorf("schema requested at %s, not at commit %s", path, h.commit)
+		}
+	}
+}
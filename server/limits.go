@@ -0,0 +1,107 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limits bound the resources each connection to the server may hold, so that
+// a handful of slow clients can't exhaust the server. Zero disables a limit.
+type Limits struct {
+	// ReadHeaderTimeout is how long a client may take to send the headers of
+	// a request
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout is how long a client may take to send a whole request
+	ReadTimeout time.Duration
+	// WriteTimeout is how long a client may take to read a response. It also
+	// bounds genesis downloads, which are exempt from the request timeout.
+	WriteTimeout time.Duration
+	// IdleTimeout is how long a keep-alive connection may wait for the next
+	// request
+	IdleTimeout time.Duration
+	// MaxHeaderBytes is the maximum size of the headers of a request
+	MaxHeaderBytes int
+	// MaxConns is the maximum number of open connections per listener.
+	// Further connections wait to be accepted.
+	MaxConns int
+}
+
+// DefaultLimits are the limits of a server unless set with WithLimits
+var DefaultLimits = Limits{
+	ReadHeaderTimeout: 10 * time.Second,
+	ReadTimeout:       time.Minute,
+	WriteTimeout:      10 * time.Minute,
+	IdleTimeout:       2 * time.Minute,
+	MaxHeaderBytes:    64 << 10,
+}
+
+// WithLimits sets the limits of the connections to the server. Defaults to
+// DefaultLimits.
+func WithLimits(limits Limits) Option {
+	return func(o *options) {
+		o.limits = limits
+	}
+}
+
+// apply sets the timeouts and header limit of the server
+func (l Limits) apply(s *http.Server) {
+	s.ReadHeaderTimeout = l.ReadHeaderTimeout
+	s.ReadTimeout = l.ReadTimeout
+	s.WriteTimeout = l.WriteTimeout
+	s.IdleTimeout = l.IdleTimeout
+	s.MaxHeaderBytes = l.MaxHeaderBytes
+}
+
+// listen listens on the address, accepting at most MaxConns connections at
+// once
+func (l Limits) listen(network, addr string) (net.Listener, error) {
+	listener, err := net.Listen(network, addr)
+	if err != nil || l.MaxConns <= 0 {
+		return listener, err
+	}
+	return &limitListener{Listener: listener, sem: make(chan struct{}, l.MaxConns), done: make(chan struct{})}, nil
+}
+
+// limitListener holds back connections once sem is full until an accepted
+// connection is closed
+type limitListener struct {
+	net.Listener
+	sem chan struct{}
+	// done is closed with the listener, as the server only closes its
+	// connections once Accept has returned
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	select {
+	case l.sem <- struct{}{}:
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		<-l.sem
+		return nil, err
+	}
+	return &limitConn{Conn: conn, release: func() { <-l.sem }}, nil
+}
+
+func (l *limitListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return l.Listener.Close()
+}
+
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
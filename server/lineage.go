@@ -0,0 +1,97 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// maxLineageSteps bounds the walk back to the origin of an asset, in case the
+// traces of the registry loop
+const maxLineageSteps = 16
+
+// assetSteps returns the traces of the chain's asset, oldest first. Assets
+// without traces fall back to their ibc section, transferred from its source
+// chain or otherwise the chain the denom is native to. The caller must hold
+// the read lock.
+func (h *Handler) assetSteps(chain string, asset types.AssetElement) []types.LineageStep {
+	if len(asset.Traces) > 0 {
+		steps := make([]types.LineageStep, len(asset.Traces))
+		for i, trace := range asset.Traces {
+			steps[i] = types.LineageStep{
+				Type:     trace.Type,
+				Chain:    trace.Counterparty.ChainName,
+				Denom:    trace.Counterparty.BaseDenom,
+				Channel:  trace.Counterparty.ChannelID,
+				Provider: trace.Provider,
+			}
+		}
+		return steps
+	}
+	if asset.Ibc == nil {
+		return nil
+	}
+	source := asset.Ibc.SourceChain
+	if source == "" {
+		source = h.chainWithBase(asset.Ibc.SourceDenom)
+	}
+	return []types.LineageStep{{
+		Type:    "ibc",
+		Chain:   source,
+		Denom:   asset.Ibc.SourceDenom,
+		Channel: asset.Ibc.SourceChannel,
+	}}
+}
+
+// assetByBase returns the chain's asset with the base denom
+func (h *Handler) assetByBase(chain, base string) (types.AssetElement, bool) {
+	for _, asset := range h.assetList[chain].Assets {
+		if asset.Base == base {
+			return asset, true
+		}
+	}
+	return types.AssetElement{}, false
+}
+
+// lineage walks the traces of the chain's asset back to its origin. At each
+// hop the traces of the asset it came from take over if the registry lists
+// it, and the earlier traces of the last listed asset are followed otherwise.
+// The caller must hold the read lock.
+func (h *Handler) lineage(chain string, asset types.AssetElement) types.AssetLineage {
+	lineage := types.AssetLineage{Chain: chain, Asset: asset, Steps: []types.LineageStep{}}
+	visited := map[string]bool{chain + "/" + asset.Base: true}
+	pending := h.assetSteps(chain, asset)
+	for len(pending) > 0 {
+		step := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		key := step.Chain + "/" + step.Denom
+		if visited[key] || len(lineage.Steps) == maxLineageSteps {
+			return lineage
+		}
+		visited[key] = true
+		if from, ok := h.assetByBase(step.Chain, step.Denom); ok {
+			step.Asset = &from
+			pending = h.assetSteps(step.Chain, from)
+		}
+		lineage.Steps = append(lineage.Steps, step)
+	}
+	steps := lineage.Steps
+	lineage.Complete = len(steps) == 0 || steps[len(steps)-1].Asset != nil
+	return lineage
+}
+
+// AssetLineage returns the hops the asset took from the asset it originates
+// from
+func (h *Handler) AssetLineage(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	asset, ok := h.findAsset(mux.Vars(req)["asset"])
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	h.respond(res, req, h.lineage(h.chainByAsset[asset.Display], asset))
+}
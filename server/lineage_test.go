@@ -0,0 +1,41 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+func TestLineage(t *testing.T) {
+	h := &Handler{assetList: map[string]types.AssetList{
+		"cosmoshub": {Assets: []types.AssetElement{{Base: "uatom"}}},
+		"osmosis": {Assets: []types.AssetElement{{
+			Base: "ibc/27394FB0",
+			Traces: []types.Trace{{
+				Type:         "ibc",
+				Counterparty: types.TraceCounterparty{ChainName: "cosmoshub", BaseDenom: "uatom", ChannelID: "channel-141"},
+			}},
+		}}},
+		"juno": {Assets: []types.AssetElement{{
+			Base: "cw20:atom",
+			Traces: []types.Trace{{
+				Type:         "ibc-cw20",
+				Counterparty: types.TraceCounterparty{ChainName: "osmosis", BaseDenom: "ibc/27394FB0"},
+			}},
+		}}},
+	}}
+
+	lineage := h.lineage("juno", h.assetList["juno"].Assets[0])
+	if !lineage.Complete {
+		t.Error("lineage ending at a listed asset without traces isn't complete")
+	}
+	var hops []string
+	for _, step := range lineage.Steps {
+		hops = append(hops, step.Type+" "+step.Chain+"/"+step.Denom)
+	}
+	want := []string{"ibc-cw20 osmosis/ibc/27394FB0", "ibc cosmoshub/uatom"}
+	if !reflect.DeepEqual(hops, want) {
+		t.Errorf("steps %v, want %v", hops, want)
+	}
+}
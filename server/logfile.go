@@ -0,0 +1,216 @@
+package server
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotatedTimeFormat names rotated log files so that they sort by age
+const rotatedTimeFormat = "2006-01-02T15-04-05.000"
+
+// LogFile writes logs to a file, rotating it once it grows past MaxSize
+// bytes or has been written to for MaxAge. Rotated files are renamed after
+// the time they were rotated, e.g. skychart-2006-01-02T15-04-05.000.log, and
+// optionally gzipped. Use it with log.SetOutput or WithLogger.
+type LogFile struct {
+	// Path of the file, e.g. /var/log/skychart.log
+	Path string
+	// MaxSize is the size in bytes a file can grow to. Zero is unlimited.
+	MaxSize int64
+	// MaxAge is how long a file is written to before it is rotated, counted
+	// across restarts. Zero never rotates a file by age.
+	MaxAge time.Duration
+	// MaxBackups is how many rotated files are kept. Zero keeps them all.
+	MaxBackups int
+	// Compress gzips rotated files
+	Compress bool
+
+	mtx    sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+
+	// cleanupMtx serializes the compression and removal of rotated files
+	cleanupMtx sync.Mutex
+}
+
+// Write appends to the file, rotating it first if it would grow past MaxSize
+// or has reached MaxAge
+func (l *LogFile) Write(p []byte) (int, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.file == nil {
+		if err := l.open(); err != nil {
+			return 0, err
+		}
+	}
+	full := l.MaxSize > 0 && l.size > 0 && l.size+int64(len(p)) > l.MaxSize
+	expired := l.MaxAge > 0 && time.Since(l.opened) >= l.MaxAge
+	if full || expired {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+	return n, err
+}
+
+// Close closes the file. It is reopened by the next write.
+func (l *LogFile) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// open opens the file for appending. The caller must hold the lock.
+func (l *LogFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(l.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	l.file, l.size, l.opened = file, info.Size(), l.started(info)
+	return nil
+}
+
+// started returns when the file was started, so that restarts don't reset
+// its age: when the last file was rotated, or if none was, when the file was
+// last modified. Empty files are started now.
+func (l *LogFile) started(info os.FileInfo) time.Time {
+	if info.Size() == 0 {
+		return time.Now()
+	}
+	backups, err := l.backups()
+	if err != nil || len(backups) == 0 {
+		return info.ModTime()
+	}
+	rotated, _ := l.rotatedAt(filepath.Base(backups[len(backups)-1]))
+	return rotated
+}
+
+// rotate moves the file aside and opens a new one, then compresses and prunes
+// the rotated files in the background. The caller must hold the lock.
+func (l *LogFile) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+	ext := filepath.Ext(l.Path)
+	rotated := fmt.Sprintf("%s-%s%s", strings.TrimSuffix(l.Path, ext), time.Now().UTC().Format(rotatedTimeFormat), ext)
+	if err := os.Rename(l.Path, rotated); err != nil {
+		return err
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+	go l.cleanup(rotated)
+	return nil
+}
+
+// cleanup compresses the rotated file, if enabled, and removes the oldest
+// rotated files beyond MaxBackups. Failures are reported to the new file.
+func (l *LogFile) cleanup(rotated string) {
+	l.cleanupMtx.Lock()
+	defer l.cleanupMtx.Unlock()
+	if l.Compress {
+		if err := gzipFile(rotated); err != nil {
+			fmt.Fprintf(l, "compressing %s: %v\n", rotated, err)
+		}
+	}
+	if l.MaxBackups <= 0 {
+		return
+	}
+	backups, err := l.backups()
+	if err != nil {
+		fmt.Fprintf(l, "listing rotated log files: %v\n", err)
+		return
+	}
+	for len(backups) > l.MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			fmt.Fprintf(l, "removing %s: %v\n", backups[0], err)
+		}
+		backups = backups[1:]
+	}
+}
+
+// backups returns the rotated files, oldest first
+func (l *LogFile) backups() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(l.Path))
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, entry := range entries {
+		if _, ok := l.rotatedAt(entry.Name()); entry.IsDir() || !ok {
+			continue
+		}
+		backups = append(backups, filepath.Join(filepath.Dir(l.Path), entry.Name()))
+	}
+	// the names sort by the time they were rotated
+	sort.Slice(backups, func(i, j int) bool {
+		return strings.TrimSuffix(backups[i], ".gz") < strings.TrimSuffix(backups[j], ".gz")
+	})
+	return backups, nil
+}
+
+// rotatedAt returns the time the rotated file with the name was rotated, or
+// false if the name isn't one of a rotated file
+func (l *LogFile) rotatedAt(name string) (time.Time, bool) {
+	ext := filepath.Ext(l.Path)
+	prefix := filepath.Base(strings.TrimSuffix(l.Path, ext)) + "-"
+	if !strings.HasPrefix(name, prefix) {
+		return time.Time{}, false
+	}
+	stamp := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".gz"), ext)
+	rotated, err := time.Parse(rotatedTimeFormat, stamp)
+	return rotated, err == nil
+}
+
+// gzipFile replaces the file with a gzipped copy
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(dst.Name())
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestLogFileAge checks that the age of a log file isn't reset when it is
+// reopened, e.g. by a restart
+func TestLogFileAge(t *testing.T) {
+	for _, backup := range []bool{false, true} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "skychart.log")
+		if err := os.WriteFile(path, []byte("before the restart\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		started := time.Now().Add(-2 * time.Hour)
+		if backup {
+			name := filepath.Join(dir, "skychart-"+started.UTC().Format(rotatedTimeFormat)+".log")
+			if err := os.WriteFile(name, []byte("rotated\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		} else if err := os.Chtimes(path, started, started); err != nil {
+			t.Fatal(err)
+		}
+
+		l := &LogFile{Path: path, MaxAge: time.Hour}
+		if _, err := l.Write([]byte("after the restart\n")); err != nil {
+			t.Fatal(err)
+		}
+		l.Close()
+		bz, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bz) != "after the restart\n" {
+			t.Errorf("backup %v: file older than max_age wasn't rotated on reopening, holds %q", backup, bz)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// defaultRetryAfter is the Retry-After of refused queries if none is set
+const defaultRetryAfter = 5 * time.Minute
+
+// Maintenance is middleware that refuses queries with 503 Service Unavailable
+// and a Retry-After header while the server is in maintenance mode, or serves
+// them with a Warning header if the maintenance is read only. The status,
+// readiness and admin routes are always served.
+func (h *Handler) Maintenance(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		h.mtx.RLock()
+		maintenance := h.maintenance
+		h.mtx.RUnlock()
+
+		path := req.URL.Path
+		if !maintenance.Enabled || path == "/status" || path == "/readyz" || strings.HasPrefix(path, "/admin/") {
+			next.ServeHTTP(res, req)
+			return
+		}
+		message := maintenance.Message
+		if message == "" {
+			message = "skychart is under maintenance"
+		}
+		if maintenance.ReadOnly {
+			res.Header().Set("Warning", fmt.Sprintf("199 skychart %q", message))
+			next.ServeHTTP(res, req)
+			return
+		}
+		res.Header().Set("Retry-After", strconv.Itoa(maintenance.RetryAfter))
+		http.Error(res, message, http.StatusServiceUnavailable)
+	})
+}
+
+// inMaintenance returns true while the server is in maintenance mode
+func (h *Handler) inMaintenance() bool {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	return h.maintenance.Enabled
+}
+
+// GetMaintenance returns the maintenance mode of the server
+func (h *Handler) GetMaintenance(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	respondWithJSON(res, h.maintenance)
+}
+
+// SetMaintenance enables maintenance mode with the message, retry_after and
+// read_only settings in the request body, if any
+func (h *Handler) SetMaintenance(res http.ResponseWriter, req *http.Request) {
+	maintenance := types.Maintenance{RetryAfter: int(defaultRetryAfter.Seconds())}
+	if req.ContentLength != 0 {
+		if err := json.NewDecoder(req.Body).Decode(&maintenance); err != nil || maintenance.RetryAfter < 0 {
+			badRequest(res)
+			return
+		}
+	}
+	maintenance.Enabled = true
+	maintenance.Since = time.Now()
+
+	h.mtx.Lock()
+	h.maintenance = maintenance
+	h.mtx.Unlock()
+
+	h.log.Printf("entered maintenance mode (read only: %t)", maintenance.ReadOnly)
+	respondWithJSON(res, maintenance)
+}
+
+// EndMaintenance leaves maintenance mode
+func (h *Handler) EndMaintenance(res http.ResponseWriter, req *http.Request) {
+	h.mtx.Lock()
+	h.maintenance = types.Maintenance{}
+	h.mtx.Unlock()
+
+	h.log.Print("left maintenance mode")
+	res.WriteHeader(http.StatusOK)
+}
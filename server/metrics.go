@@ -0,0 +1,183 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// fetchBuckets are the upper bounds, in seconds, of the fetch latency
+// histogram
+var fetchBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// fetchMetrics records the requests made to github while pulling the registry
+type fetchMetrics struct {
+	mtx      sync.Mutex
+	requests int64
+	bytes    int64
+	files    map[string]*fetchLatency // file name or "api" -> latency
+}
+
+// fetchLatency is a histogram of fetch latencies
+type fetchLatency struct {
+	count   int64
+	sum     float64
+	max     float64
+	buckets []int64 // count of fetches within each of fetchBuckets
+}
+
+func newFetchMetrics() *fetchMetrics {
+	return &fetchMetrics{files: make(map[string]*fetchLatency)}
+}
+
+// client returns a copy of the client that records its requests
+func (m *fetchMetrics) client(client *http.Client) *http.Client {
+	measured := *client
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	measured.Transport = measuredTransport{metrics: m, next: transport}
+	return &measured
+}
+
+// observe records a fetch of the file that downloaded n bytes
+func (m *fetchMetrics) observe(file string, n int64, latency time.Duration) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.requests++
+	m.bytes += n
+	l, ok := m.files[file]
+	if !ok {
+		l = &fetchLatency{buckets: make([]int64, len(fetchBuckets))}
+		m.files[file] = l
+	}
+	seconds := latency.Seconds()
+	l.count++
+	l.sum += seconds
+	if seconds > l.max {
+		l.max = seconds
+	}
+	for i, bound := range fetchBuckets {
+		if seconds <= bound {
+			l.buckets[i]++
+		}
+	}
+}
+
+// snapshot returns the metrics as reported by /status
+func (m *fetchMetrics) snapshot() types.FetchMetrics {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	metrics := types.FetchMetrics{
+		Requests: m.requests,
+		Bytes:    m.bytes,
+		Files:    make(map[string]types.FetchLatency, len(m.files)),
+	}
+	for file, l := range m.files {
+		metrics.Files[file] = types.FetchLatency{Count: l.count, Mean: l.sum / float64(l.count), Max: l.max}
+	}
+	return metrics
+}
+
+// fetchedFile names the file of the registry that the request fetches, or
+// "api" for the other requests to github
+func fetchedFile(req *http.Request) string {
+	if file := path.Base(req.URL.Path); strings.HasSuffix(file, ".json") {
+		return file
+	}
+	return "api"
+}
+
+// measuredTransport records the latency and size of each response. A fetch
+// lasts until its body is closed.
+type measuredTransport struct {
+	metrics *fetchMetrics
+	next    http.RoundTripper
+}
+
+func (t measuredTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.metrics.observe(fetchedFile(req), 0, time.Since(start))
+		return nil, err
+	}
+	resp.Body = &measuredBody{ReadCloser: resp.Body, metrics: t.metrics, file: fetchedFile(req), start: start}
+	return resp, nil
+}
+
+type measuredBody struct {
+	io.ReadCloser
+	metrics *fetchMetrics
+	file    string
+	start   time.Time
+	n       int64
+	closed  bool
+}
+
+func (b *measuredBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *measuredBody) Close() error {
+	if !b.closed {
+		b.closed = true
+		b.metrics.observe(b.file, b.n, time.Since(b.start))
+	}
+	return b.ReadCloser.Close()
+}
+
+// Metrics serves the state of the registry, the github rate limit and the
+// fetches made while pulling in the Prometheus text format
+func (h *Handler) Metrics(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	status := h.status(time.Now())
+	h.mtx.RUnlock()
+
+	var b strings.Builder
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", name, help, name, name, value)
+	}
+	gauge("skychart_registry_age_seconds", "Seconds since the registry was last updated.", float64(status.Age))
+	gauge("skychart_last_pull_duration_seconds", "Duration of the last pull.", status.LastPullDuration)
+	gauge("skychart_chain_errors", "Chains that failed to update in the last pull.", float64(len(status.ChainErrors)))
+	if status.RateLimit != nil {
+		gauge("skychart_github_rate_limit", "Requests allowed by the github API quota.", float64(status.RateLimit.Limit))
+		gauge("skychart_github_rate_limit_remaining", "Requests remaining in the github API quota.", float64(status.RateLimit.Remaining))
+	}
+
+	h.fetches.mtx.Lock()
+	defer h.fetches.mtx.Unlock()
+	fmt.Fprintf(&b, "# HELP skychart_upstream_requests_total Requests made to github while pulling.\n"+
+		"# TYPE skychart_upstream_requests_total counter\nskychart_upstream_requests_total %d\n", h.fetches.requests)
+	fmt.Fprintf(&b, "# HELP skychart_upstream_bytes_total Bytes downloaded from github while pulling.\n"+
+		"# TYPE skychart_upstream_bytes_total counter\nskychart_upstream_bytes_total %d\n", h.fetches.bytes)
+
+	const histogram = "skychart_upstream_fetch_duration_seconds"
+	fmt.Fprintf(&b, "# HELP %s Latency of the requests to github by file.\n# TYPE %s histogram\n", histogram, histogram)
+	files := make([]string, 0, len(h.fetches.files))
+	for file := range h.fetches.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		l := h.fetches.files[file]
+		for i, bound := range fetchBuckets {
+			fmt.Fprintf(&b, "%s_bucket{file=%q,le=\"%g\"} %d\n", histogram, file, bound, l.buckets[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket{file=%q,le=\"+Inf\"} %d\n", histogram, file, l.count)
+		fmt.Fprintf(&b, "%s_sum{file=%q} %g\n%s_count{file=%q} %d\n", histogram, file, l.sum, histogram, file, l.count)
+	}
+
+	respondWithText(res, "text/plain; version=0.0.4", b.String())
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// mirror serves the complete tree of the registry, as of the last pull, in
+// the layout of the repo. Each commit is extracted into a new directory under
+// dir, which replaces the previous one once it is complete.
+type mirror struct {
+	dir string
+
+	mtx    sync.RWMutex
+	root   string // directory of the tree being served, empty until mirrored
+	commit string
+}
+
+// SetMirror serves the complete tree of the registry under /mirror, kept in
+// dir. A registry pulled from a directory is served from the directory
+// itself.
+func (h *Handler) SetMirror(dir string) {
+	h.mirror = &mirror{dir: dir}
+}
+
+// updateMirror mirrors the commit of the registry from the source unless it
+// has already been mirrored
+func (h *Handler) updateMirror(ctx context.Context, source Source, commit string) error {
+	if dir, ok := source.(DirSource); ok {
+		h.mirror.mtx.Lock()
+		h.mirror.root = dir.Root
+		h.mirror.mtx.Unlock()
+		return nil
+	}
+	tree, ok := source.(treeSource)
+	if !ok {
+		return errors.New("the source can't be mirrored")
+	}
+	h.mirror.mtx.RLock()
+	mirrored := h.mirror.root != "" && h.mirror.commit == commit
+	h.mirror.mtx.RUnlock()
+	if mirrored || commit == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(h.mirror.dir, 0o755); err != nil {
+		return err
+	}
+	root, err := os.MkdirTemp(h.mirror.dir, "tree-")
+	if err != nil {
+		return err
+	}
+	if err := tree.Tree(ctx, commit, root); err != nil {
+		os.RemoveAll(root)
+		return fmt.Errorf("mirroring %s: %w", commit, err)
+	}
+
+	h.mirror.mtx.Lock()
+	previous := h.mirror.root
+	h.mirror.root, h.mirror.commit = root, commit
+	h.mirror.mtx.Unlock()
+	// files being served from the previous tree remain readable once removed.
+	// Before the first tree, the trees left by a previous run are removed.
+	stale := []string{previous}
+	if previous == "" {
+		stale, _ = filepath.Glob(filepath.Join(h.mirror.dir, "tree-*"))
+	}
+	for _, dir := range stale {
+		if dir != root {
+			os.RemoveAll(dir)
+		}
+	}
+	h.log.Printf("mirrored registry at %s", commit)
+	return nil
+}
+
+// Mirror serves the files of the mirrored registry under /mirror, along with
+// listings of its directories. Hidden files, such as a .git directory of a
+// registry pulled from a directory, aren't served.
+func (h *Handler) Mirror(res http.ResponseWriter, req *http.Request) {
+	h.mirror.mtx.RLock()
+	root := h.mirror.root
+	h.mirror.mtx.RUnlock()
+
+	if root == "" {
+		http.Error(res, "the registry hasn't been mirrored yet", http.StatusServiceUnavailable)
+		return
+	}
+	setCORSHeaders(res)
+	// the mirror serves the registry's SVG logos too
+	setUntrustedContentHeaders(res)
+	http.StripPrefix("/mirror", http.FileServer(visibleFiles{http.Dir(root)})).ServeHTTP(res, req)
+}
+
+// visibleFiles hides the files and directories whose name starts with a dot
+type visibleFiles struct {
+	http.FileSystem
+}
+
+func (fs visibleFiles) Open(name string) (http.File, error) {
+	if strings.Contains(name, "/.") {
+		return nil, os.ErrNotExist
+	}
+	file, err := fs.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return visibleFile{file}, nil
+}
+
+type visibleFile struct {
+	http.File
+}
+
+func (f visibleFile) Readdir(n int) ([]os.FileInfo, error) {
+	entries, err := f.File.Readdir(n)
+	visible := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), ".") {
+			visible = append(visible, entry)
+		}
+	}
+	return visible, err
+}
+
+// downloadTree extracts the gzipped tarball at query into dir
+func downloadTree(ctx context.Context, client *http.Client, header http.Header, query, dir string) error {
+	tarball, found, err := stream(ctx, client, header, query, nil)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no tarball at %s", query)
+	}
+	defer tarball.Close()
+	return extractTarball(tarball, dir)
+}
+
+// extractTarball writes the directories and regular files of a gzipped
+// tarball into dir. As with readArchive, the directory the repo is nested in
+// is stripped.
+func extractTarball(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		parts := strings.SplitN(strings.Trim(header.Name, "/"), "/", 2)
+		if len(parts) < 2 {
+			continue
+		}
+		// cleaning the path as rooted keeps it within dir
+		name := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+parts[1])))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(name, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+				return err
+			}
+			if err := writeFile(name, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFile(name string, r io.Reader) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
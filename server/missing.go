@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// missingFileTTL is how long a chain file found missing isn't requested again,
+// unless the listing of the registry shows it sooner
+const missingFileTTL = 24 * time.Hour
+
+// listingSource is implemented by sources that can list the files of every
+// chain at once. Files such a source was missing aren't requested again by
+// later pulls until the listing shows them, see missingFiles.
+type listingSource interface {
+	// Listing returns the paths of the files in the chain directories, e.g.
+	// osmosis/chain.json
+	Listing(ctx context.Context) (map[string]bool, error)
+}
+
+// missingFiles records when pulls found the chain files missing, keyed by
+// their path, e.g. osmosis/assetlist.json, so that chains without a chain.json
+// or assetlist.json don't cost a request that is certain to fail on every
+// pull
+type missingFiles struct {
+	mtx     sync.Mutex
+	enabled bool
+	since   map[string]time.Time
+}
+
+func newMissingFiles() *missingFiles {
+	return &missingFiles{since: make(map[string]time.Time)}
+}
+
+// refresh forgets the files that were found missing more than missingFileTTL
+// ago or that the listing of the source shows, and is called at the start of
+// every pull. Missing files are only recorded for sources that can list their
+// files. If the listing fails every file is forgotten.
+func (m *missingFiles) refresh(ctx context.Context, source Source) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	lister, ok := source.(listingSource)
+	m.enabled = ok
+	if !ok {
+		m.since = make(map[string]time.Time)
+		return nil
+	}
+	for path, since := range m.since {
+		if time.Since(since) > missingFileTTL {
+			delete(m.since, path)
+		}
+	}
+	if len(m.since) == 0 {
+		return nil
+	}
+	listing, err := lister.Listing(ctx)
+	if err != nil {
+		m.since = make(map[string]time.Time)
+		return err
+	}
+	for path := range m.since {
+		if listing[path] {
+			delete(m.since, path)
+		}
+	}
+	return nil
+}
+
+// skip reports whether the file is known to be missing
+func (m *missingFiles) skip(path string) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	_, ok := m.since[path]
+	return ok
+}
+
+// add records that the file was found missing
+func (m *missingFiles) add(path string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.enabled {
+		m.since[path] = time.Now()
+	}
+}
+
+// openChainFile opens the file of the chain like openFile, unless an earlier
+// pull found it missing
+func (h *Handler) openChainFile(ctx context.Context, source Source, chain, file string) (io.ReadCloser, bool, error) {
+	path := chain + "/" + file
+	if h.missing.skip(path) {
+		return nil, false, nil
+	}
+	r, ok, err := openFile(ctx, source, chain, file)
+	if err == nil && !ok {
+		h.missing.add(path)
+	}
+	return r, ok, err
+}
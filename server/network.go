@@ -0,0 +1,33 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+)
+
+// chainNetwork returns the chain id without its revision number, e.g.
+// osmosis for osmosis-1, following the {identifier}-{revision} format of
+// IBC. Chain ids without a revision number are returned as they are.
+func chainNetwork(chainID string) string {
+	i := strings.LastIndex(chainID, "-")
+	if i <= 0 {
+		return chainID
+	}
+	if _, err := strconv.ParseUint(chainID[i+1:], 10, 64); err != nil {
+		return chainID
+	}
+	return chainID[:i]
+}
+
+// chainsWithIDPrefix returns the chains whose id starts with the prefix,
+// ignoring case. The caller must hold the read lock.
+func (h *Handler) chainsWithIDPrefix(chains []string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	filtered := make([]string, 0)
+	for _, name := range chains {
+		if strings.HasPrefix(strings.ToLower(h.chainList[name].ChainID), prefix) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
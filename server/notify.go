@@ -0,0 +1,163 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// notifyTimeout bounds how long a notifier can take to deliver a notification
+const notifyTimeout = 10 * time.Second
+
+// Notifier is informed whenever an update changes the registry
+type Notifier interface {
+	Notify(ctx context.Context, registry string, changes types.Changes) error
+}
+
+// AddNotifier registers a notifier to be called after every update that
+// changes the registry
+func (h *Handler) AddNotifier(notifier Notifier) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.notifiers = append(h.notifiers, notifier)
+}
+
+// notify delivers the changes to every notifier in the background. The caller
+// must hold the lock.
+func (h *Handler) notify(changes types.Changes) {
+	notifiers := make([]Notifier, 0, len(h.optNotifiers)+len(h.notifiers))
+	for _, notifier := range append(append(notifiers, h.optNotifiers...), h.notifiers...) {
+		go func(notifier Notifier) {
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			defer cancel()
+			if err := notifier.Notify(ctx, h.registryUrl, changes); err != nil {
+				h.log.Printf("notifying of registry changes: %v", err)
+			}
+		}(notifier)
+	}
+}
+
+// Webhook POSTs a JSON description of the changes to a URL
+type Webhook struct {
+	URL string
+	// Client makes the requests. Defaults to a client shared by skychart.
+	Client *http.Client
+}
+
+// WebhookPayload is the body of the request sent by a Webhook
+type WebhookPayload struct {
+	Registry  string        `json:"registry"`
+	Timestamp time.Time     `json:"timestamp"`
+	Changes   types.Changes `json:"changes"`
+}
+
+func (w Webhook) Notify(ctx context.Context, registry string, changes types.Changes) error {
+	bz, err := json.Marshal(WebhookPayload{
+		Registry:  registry,
+		Timestamp: time.Now(),
+		Changes:   changes,
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, w.Client, w.URL, bz)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient(client).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code from %s: %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+// Slack posts a human readable summary of the changes to a Slack incoming webhook
+type Slack struct {
+	WebhookURL string
+	// Client makes the requests. Defaults to a client shared by skychart.
+	Client *http.Client
+}
+
+func (s Slack) Notify(ctx context.Context, registry string, changes types.Changes) error {
+	bz, err := json.Marshal(map[string]string{"text": summarize(registry, changes)})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, s.Client, s.WebhookURL, bz)
+}
+
+// discordMessageLimit is the maximum length of a Discord message, in
+// characters
+const discordMessageLimit = 2000
+
+// Discord posts a human readable summary of the changes to a Discord webhook
+type Discord struct {
+	WebhookURL string
+	// Client makes the requests. Defaults to a client shared by skychart.
+	Client *http.Client
+}
+
+// truncate shortens s to at most limit runes, ending it with "..." if it was
+// cut, without splitting multi-byte characters
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := 0
+	for i := range s {
+		if runes == limit-3 {
+			return s[:i] + "..."
+		}
+		runes++
+	}
+	return s
+}
+
+func (d Discord) Notify(ctx context.Context, registry string, changes types.Changes) error {
+	summary := summarize(registry, changes)
+	bz, err := json.Marshal(map[string]string{"content": truncate(summary, discordMessageLimit)})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, d.Client, d.WebhookURL, bz)
+}
+
+// summarize describes the changes in a line per kind of change, e.g.
+// "2 chains modified: cosmoshub, osmosis"
+func summarize(registry string, changes types.Changes) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s was updated", registry)
+	describe := func(names []string, singular, plural, action string) {
+		if len(names) == 0 {
+			return
+		}
+		noun := plural
+		if len(names) == 1 {
+			noun = singular
+		}
+		fmt.Fprintf(&sb, "\n%d %s %s: %s", len(names), noun, action, strings.Join(names, ", "))
+	}
+	describe(changes.Chains.Added, "chain", "chains", "added")
+	describe(changes.Chains.Removed, "chain", "chains", "removed")
+	describe(changes.Chains.Modified, "chain", "chains", "modified")
+	describe(changes.Assets.Added, "asset", "assets", "added")
+	describe(changes.Assets.Removed, "asset", "assets", "removed")
+	describe(changes.Assets.Modified, "asset", "assets", "modified")
+	return sb.String()
+}
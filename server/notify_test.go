@@ -0,0 +1,24 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("osmosis", 7); got != "osmosis" {
+		t.Errorf("truncate of a short enough string = %q", got)
+	}
+	if got := truncate("osmosis", 6); got != "osm..." {
+		t.Errorf("truncate = %q, want osm...", got)
+	}
+	long := strings.Repeat("é", discordMessageLimit+1)
+	got := truncate(long, discordMessageLimit)
+	if !utf8.ValidString(got) {
+		t.Error("truncate split a rune")
+	}
+	if n := utf8.RuneCountInString(got); n != discordMessageLimit {
+		t.Errorf("truncated to %d runes, want %d", n, discordMessageLimit)
+	}
+}
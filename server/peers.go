@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// peerDialTimeout bounds the liveness check of a peer
+	peerDialTimeout = 3 * time.Second
+	// bucketTypeNew marks the addresses of an address book that were never
+	// connected to
+	bucketTypeNew = 1
+)
+
+// P2PConfig returns the p2p settings of a node of the chain, the seeds and
+// persistent peers, as the [p2p] section of a Tendermint config.toml.
+// ?external_address= fills in the address other peers reach the node at and
+// ?format=json returns the settings as JSON instead of TOML.
+func (h *Handler) P2PConfig(res http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	format := query.Get("format")
+	if format != "" && format != "toml" && format != "json" {
+		badRequest(res)
+		return
+	}
+	externalAddress := query.Get("external_address")
+	if externalAddress != "" {
+		if _, _, err := net.SplitHostPort(externalAddress); err != nil {
+			badRequest(res)
+			return
+		}
+	}
+	seeds, peers, ok := h.chainPeers(req)
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	config := types.NodeConfig{
+		Seeds:           joinPeers(seeds),
+		PersistentPeers: joinPeers(peers),
+		ExternalAddress: externalAddress,
+	}
+	if format == "json" {
+		respondWithJSON(res, config)
+		return
+	}
+	var b strings.Builder
+	b.WriteString("[p2p]\n")
+	if config.ExternalAddress != "" {
+		fmt.Fprintf(&b, "external_address = %s\n", tomlString(config.ExternalAddress))
+	}
+	fmt.Fprintf(&b, "seeds = %s\npersistent_peers = %s\n", tomlString(config.Seeds), tomlString(config.PersistentPeers))
+	respondWithText(res, "application/toml", b.String())
+}
+
+// AddrBook returns the seeds and persistent peers of a chain as a Tendermint
+// addrbook.json. Peers with a hostname are resolved, since the address book
+// only holds IPs.
+func (h *Handler) AddrBook(res http.ResponseWriter, req *http.Request) {
+	seeds, peers, ok := h.chainPeers(req)
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	chain := mux.Vars(req)["chain"]
+	key := sha256.Sum256([]byte(chain))
+	book := types.AddrBook{
+		// the key only seeds the node's bucket hashing, so a stable key
+		// derived from the chain is as good as a random one
+		Key:   hex.EncodeToString(key[:12]),
+		Addrs: make([]types.KnownAddress, 0, len(seeds)+len(peers)),
+	}
+	// the peers are copied rather than appended to the seeds, which share
+	// their array with the registry
+	all := make([]types.PersistentPeerElement, 0, len(seeds)+len(peers))
+	all = append(append(all, seeds...), peers...)
+	seen := make(map[string]bool)
+	for _, peer := range all {
+		if seen[peer.ID] {
+			continue
+		}
+		seen[peer.ID] = true
+		addr, err := netAddress(req.Context(), peer)
+		if err != nil {
+			continue
+		}
+		book.Addrs = append(book.Addrs, types.KnownAddress{
+			Addr:       addr,
+			Src:        addr,
+			Buckets:    []int{},
+			BucketType: bucketTypeNew,
+		})
+	}
+	respondWithJSON(res, book)
+}
+
+// chainPeers returns the seeds and persistent peers of the chain in the
+// request. If the "live" query parameter is true, only the peers that accept
+// a connection are returned.
+func (h *Handler) chainPeers(req *http.Request) (seeds, peers []types.PersistentPeerElement, ok bool) {
+	// the lock is released before the peers are checked
+	h.mtx.RLock()
+	exists, chain := h.findChain(mux.Vars(req)["chain"])
+	h.mtx.RUnlock()
+
+	if !exists {
+		return nil, nil, false
+	}
+	if chain.Peers != nil {
+		seeds, peers = chain.Peers.Seeds, chain.Peers.PersistentPeers
+	}
+	if req.URL.Query().Get("live") == "true" {
+		seeds, peers = livePeers(req.Context(), seeds), livePeers(req.Context(), peers)
+	}
+	return seeds, peers, true
+}
+
+// livePeers dials every peer concurrently and returns those that accepted the
+// connection, in their original order
+func livePeers(ctx context.Context, peers []types.PersistentPeerElement) []types.PersistentPeerElement {
+	live := make([]bool, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			dialer := net.Dialer{Timeout: peerDialTimeout}
+			conn, err := dialer.DialContext(ctx, "tcp", address)
+			if err != nil {
+				return
+			}
+			conn.Close()
+			live[i] = true
+		}(i, peer.Address)
+	}
+	wg.Wait()
+
+	result := make([]types.PersistentPeerElement, 0, len(peers))
+	for i, peer := range peers {
+		if live[i] {
+			result = append(result, peer)
+		}
+	}
+	return result
+}
+
+// joinPeers formats peers as the comma separated id@host:port list used by
+// config.toml
+func joinPeers(peers []types.PersistentPeerElement) string {
+	entries := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		entries = append(entries, peer.ID+"@"+peer.Address)
+	}
+	return strings.Join(entries, ",")
+}
+
+// tomlString quotes s as a TOML basic string. Unlike Go's %q, which escapes
+// non-printable runes as \x or \U and isn't valid TOML, control characters are
+// escaped as \uXXXX and everything else is kept as is.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// netAddress converts a peer into an address book entry, resolving its host
+// if it isn't an IP
+func netAddress(ctx context.Context, peer types.PersistentPeerElement) (types.NetAddress, error) {
+	host, portStr, err := net.SplitHostPort(peer.Address)
+	if err != nil {
+		return types.NetAddress{}, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return types.NetAddress{}, err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		ctx, cancel := context.WithTimeout(ctx, peerDialTimeout)
+		defer cancel()
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+		if err != nil {
+			return types.NetAddress{}, err
+		}
+		ip = ips[0]
+	}
+	return types.NetAddress{ID: peer.ID, IP: ip.String(), Port: uint16(port)}, nil
+}
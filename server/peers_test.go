@@ -0,0 +1,17 @@
+package server
+
+import "testing"
+
+func TestTOMLString(t *testing.T) {
+	for s, want := range map[string]string{
+		"id@host:26656":   `"id@host:26656"`,
+		`a"b\c`:           `"a\"b\\c"`,
+		"line\nbreak\x7f": `"line\nbreak\u007F"`,
+		"bell\a":          `"bell\u0007"`,
+		"ünïcode":         `"ünïcode"`,
+	} {
+		if got := tomlString(s); got != want {
+			t.Errorf("tomlString(%q) = %s, want %s", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// Registry is a read-only view of the served registry. Its maps must not be
+// modified or kept beyond the call they are passed to.
+type Registry struct {
+	Chains     map[string]types.Chain
+	AssetLists map[string]types.AssetList
+	// Files holds each file of the registry as pulled, keyed by its path,
+	// e.g. osmosis/chain.json, which keeps the fields the types don't know
+	// about. It is empty for a registry restored from a snapshot.
+	Files map[string][]byte
+}
+
+// IndexFunc computes a custom index of the registry, such as the chains that
+// enable CosmWasm. It is called whenever the registry changes and its result
+// is served as JSON at /v1/indexes/{name}.
+type IndexFunc func(registry Registry) interface{}
+
+type customIndex struct {
+	name  string
+	build IndexFunc
+}
+
+// AddIndex registers a custom index that is computed from the next change to
+// the registry onwards
+func (h *Handler) AddIndex(name string, build IndexFunc) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.indexFuncs = append(h.indexFuncs, customIndex{name: name, build: build})
+}
+
+// buildIndexes computes the custom indexes. The caller must hold the write
+// lock.
+func (h *Handler) buildIndexes() {
+	h.indexes = make(map[string]interface{}, len(h.indexFuncs))
+	registry := h.registry()
+	for _, index := range h.indexFuncs {
+		h.indexes[index.name] = index.build(registry)
+	}
+}
+
+// registry returns a view of the registry. The caller must hold the lock.
+func (h *Handler) registry() Registry {
+	return Registry{Chains: h.chainList, AssetLists: h.assetList, Files: h.files}
+}
+
+// Index returns the current value of the custom index, for routes mounted with
+// WithRoutes
+func (h *Handler) Index(name string) (interface{}, bool) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	index, ok := h.indexes[name]
+	return index, ok
+}
+
+// View calls fn with a view of the registry, for routes mounted with
+// WithRoutes. The registry isn't updated until fn returns.
+func (h *Handler) View(fn func(registry Registry)) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	fn(h.registry())
+}
+
+// Indexes returns the names of the custom indexes
+func (h *Handler) Indexes(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	names := make([]string, 0, len(h.indexes))
+	for name := range h.indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	respondWithJSON(res, names)
+}
+
+// CustomIndex returns the current value of a custom index
+func (h *Handler) CustomIndex(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	index, ok := h.indexes[mux.Vars(req)["name"]]
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	h.respond(res, req, index)
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// TestPostgresStore runs against the database at the DSN in
+// SKYCHART_TEST_POSTGRES, e.g. postgres://postgres@localhost/skychart?sslmode=disable,
+// and is skipped if it isn't set
+func TestPostgresStore(t *testing.T) {
+	dsn := os.Getenv("SKYCHART_TEST_POSTGRES")
+	if dsn == "" {
+		t.Skip("SKYCHART_TEST_POSTGRES isn't set")
+	}
+	ctx := context.Background()
+	registry := fmt.Sprintf("test/registry-%d", time.Now().UnixNano())
+	store, err := NewPostgresStore(dsn, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	defer func() {
+		for _, table := range []string{"registries", "chains", "chain_versions", "asset_lists", "assets"} {
+			if _, err := store.db.Exec("DELETE FROM "+table+" WHERE registry = $1", registry); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+
+	snapshot, err := store.Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !snapshot.Timestamp.IsZero() || len(snapshot.Chains) != 0 {
+		t.Fatalf("empty store loaded %d chains at %s", len(snapshot.Chains), snapshot.Timestamp)
+	}
+
+	symbol := "OSMO"
+	saved := Snapshot{
+		Registry:  registry,
+		Branch:    "master",
+		Timestamp: time.Now().UTC().Truncate(time.Microsecond),
+		Chains: map[string]types.Chain{
+			"osmosis":   {ChainName: "osmosis", ChainID: "osmosis-1", Bech32Prefix: "osmo"},
+			"cosmoshub": {ChainName: "cosmoshub", ChainID: "cosmoshub-4", Bech32Prefix: "cosmos"},
+		},
+		AssetLists: map[string]types.AssetList{
+			"osmosis": {ChainID: "osmosis-1", Assets: []types.AssetElement{
+				{Base: "uosmo", Display: "osmo", Symbol: &symbol, DenomUnits: []types.DenomUnitElement{{Denom: "uosmo"}}},
+			}},
+		},
+	}
+	if err := store.Save(ctx, saved); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Timestamp.Equal(saved.Timestamp) || loaded.Branch != saved.Branch {
+		t.Errorf("loaded branch %s at %s, want %s at %s", loaded.Branch, loaded.Timestamp, saved.Branch, saved.Timestamp)
+	}
+	if !reflect.DeepEqual(loaded.Chains, saved.Chains) {
+		t.Errorf("loaded chains %+v, want %+v", loaded.Chains, saved.Chains)
+	}
+	if !reflect.DeepEqual(loaded.AssetLists, saved.AssetLists) {
+		t.Errorf("loaded asset lists %+v, want %+v", loaded.AssetLists, saved.AssetLists)
+	}
+
+	// a changed chain gets a new version and a removed one is dropped
+	saved.Timestamp = saved.Timestamp.Add(time.Hour)
+	saved.Chains = map[string]types.Chain{
+		"osmosis": {ChainName: "osmosis", ChainID: "osmosis-2", Bech32Prefix: "osmo"},
+	}
+	if err := store.Save(ctx, saved); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = store.Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Chains, saved.Chains) {
+		t.Errorf("loaded chains %+v after the second save, want %+v", loaded.Chains, saved.Chains)
+	}
+	var versions int
+	err = store.db.QueryRow(`SELECT count(*) FROM chain_versions WHERE registry = $1 AND name = 'osmosis'`, registry).Scan(&versions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if versions != 2 {
+		t.Errorf("%d versions of osmosis kept, want 2", versions)
+	}
+	var assets int
+	err = store.db.QueryRow(`SELECT count(*) FROM assets WHERE registry = $1 AND symbol = 'OSMO'`, registry).Scan(&assets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assets != 1 {
+		t.Errorf("%d OSMO assets stored, want 1", assets)
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// PrettyJSON is middleware that indents JSON responses when the request has
+// ?pretty=true, for humans reading the API with curl. Responses are compact by
+// default.
+func PrettyJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("pretty") != "true" {
+			next.ServeHTTP(res, req)
+			return
+		}
+		rewriteJSON(res, req, next, func(body []byte) ([]byte, error) {
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, body, "", "  "); err != nil {
+				return nil, err
+			}
+			indented.WriteByte('\n')
+			return indented.Bytes(), nil
+		})
+	})
+}
+
+// rewriteJSON serves the request with next and rewrites the body of the
+// response if it is JSON. The response is left as is if rewrite fails.
+func rewriteJSON(res http.ResponseWriter, req *http.Request, next http.Handler, rewrite func([]byte) ([]byte, error)) {
+	buf := &bufferedWriter{ResponseWriter: res, status: http.StatusOK}
+	next.ServeHTTP(buf, req)
+
+	body := buf.body.Bytes()
+	if strings.HasPrefix(res.Header().Get("Content-Type"), "application/json") && buf.status == http.StatusOK {
+		if rewritten, err := rewrite(body); err == nil {
+			body = rewritten
+			res.Header().Del("Content-Length")
+		}
+	}
+	res.WriteHeader(buf.status)
+	_, _ = res.Write(body)
+}
+
+// bufferedWriter holds back the response so that it can be rewritten
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// maxPreviews bounds the number of previews, each of which holds an entire
+	// copy of the registry
+	maxPreviews = 16
+	// previewPullTimeout bounds the pulls of previews, which run in the
+	// background
+	previewPullTimeout = 10 * time.Minute
+)
+
+// States of a preview
+const (
+	previewLoading = "loading"
+	previewReady   = "ready"
+	previewFailed  = "failed"
+)
+
+// previewName matches the names previews are served under
+var previewName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// previews holds the registry at other git refs than the one served, so that
+// changes to the registry can be reviewed as skychart would serve them
+type previews struct {
+	mtx    sync.RWMutex
+	opts   options // the routes of each preview are registered with
+	byName map[string]*preview
+}
+
+// preview is the registry at a ref. Its fields other than the handler and
+// router are guarded by the lock of the previews.
+type preview struct {
+	handler *Handler
+	router  *mux.Router
+	ref     string
+	loaded  time.Time
+	// state is previewLoading while the ref is pulled, then previewReady or
+	// previewFailed with the error of the pull in err
+	state string
+	err   string
+}
+
+func newPreviews() *previews {
+	return &previews{byName: make(map[string]*preview)}
+}
+
+// previewHandler returns a handler that pulls the registry at the ref with the
+// same settings as this one. Only registries pulled from github have refs.
+func (h *Handler) previewHandler(ref string) *Handler {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	preview := NewHandler(h.registryUrl, h.log)
+	preview.SetUpstream(h.apiUrl, h.rawUrl)
+	preview.SetHTTPClient(h.client)
+	// genesis files are the same whichever ref lists them
+	preview.genesis = h.genesis
+	preview.SetBranch(ref)
+	preview.SetArchive(h.archive)
+	preview.SetGraphQL(h.graphql)
+	preview.SetGitHubToken(h.githubToken)
+	preview.filter = h.filter
+	preview.SetAliases(h.aliases)
+	preview.SetPreviousChainIDs(h.prevIDs)
+	preview.indexFuncs = h.indexFuncs
+	return preview
+}
+
+// newPreview returns a preview of the ref, served with the same routes and
+// registry middleware as the served registry
+func (h *Handler) newPreview(ref string, opts options) *preview {
+	p := &preview{handler: h.previewHandler(ref), router: mux.NewRouter(), ref: ref}
+	useRegistryMiddleware(p.router, p.handler)
+	for _, version := range apiVersions {
+		version.register(p.router.PathPrefix("/"+version.prefix).Subrouter(), p.handler, opts)
+	}
+	return p
+}
+
+// info describes the preview. The caller must hold the lock of the previews.
+func (p *preview) info(name string) types.Preview {
+	h := p.handler
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	return types.Preview{
+		Name:     name,
+		Ref:      p.ref,
+		State:    p.state,
+		Error:    p.err,
+		Commit:   h.commit,
+		LoadedAt: p.loaded,
+		Chains:   len(h.chains),
+	}
+}
+
+// loadPreview pulls the ref of the preview and records the outcome
+func (h *Handler) loadPreview(name string, p *preview) {
+	ctx, cancel := context.WithTimeout(context.Background(), previewPullTimeout)
+	defer cancel()
+	err := p.handler.Pull(ctx)
+
+	h.previews.mtx.Lock()
+	defer h.previews.mtx.Unlock()
+	if err != nil {
+		h.log.Printf("failed to load preview %s of %s: %v", name, p.ref, err)
+		p.state, p.err = previewFailed, err.Error()
+		return
+	}
+	h.log.Printf("loaded preview %s of %s", name, p.ref)
+	p.state, p.err, p.loaded = previewReady, "", time.Now()
+}
+
+// CreatePreview loads the registry at the git ref in ?ref= under
+// /preview/{name}, e.g. refs/pull/1234/head. A numeric name without a ref
+// previews the pull request with that number. Loading an existing preview
+// again pulls the latest changes of its ref. The ref is pulled in the
+// background, and the preview is returned in the loading state.
+func (h *Handler) CreatePreview(res http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)["name"]
+	ref := req.URL.Query().Get("ref")
+	if ref == "" {
+		if _, err := strconv.ParseUint(name, 10, 64); err == nil {
+			ref = "refs/pull/" + name + "/head"
+		}
+	}
+	h.mtx.RLock()
+	source := h.source
+	h.mtx.RUnlock()
+	if !previewName.MatchString(name) || ref == "" || source != nil {
+		badRequest(res)
+		return
+	}
+
+	// the slot is reserved before the pull so that concurrent requests can't
+	// exceed maxPreviews
+	h.previews.mtx.Lock()
+	p, exists := h.previews.byName[name]
+	if !exists && len(h.previews.byName) >= maxPreviews {
+		h.previews.mtx.Unlock()
+		badRequest(res)
+		return
+	}
+	if !exists || p.ref != ref {
+		p = h.newPreview(ref, h.previews.opts)
+		h.previews.byName[name] = p
+	}
+	p.state = previewLoading
+	info := p.info(name)
+	h.previews.mtx.Unlock()
+
+	go h.loadPreview(name, p)
+	respondWithJSON(res, info)
+}
+
+// ListPreviews returns the previews, ordered by name
+func (h *Handler) ListPreviews(res http.ResponseWriter, req *http.Request) {
+	h.previews.mtx.RLock()
+	defer h.previews.mtx.RUnlock()
+
+	previews := make([]types.Preview, 0, len(h.previews.byName))
+	for name, p := range h.previews.byName {
+		previews = append(previews, p.info(name))
+	}
+	sort.Slice(previews, func(i, j int) bool { return previews[i].Name < previews[j].Name })
+	respondWithJSON(res, previews)
+}
+
+// DeletePreview stops serving a preview
+func (h *Handler) DeletePreview(res http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)["name"]
+	h.previews.mtx.Lock()
+	_, ok := h.previews.byName[name]
+	delete(h.previews.byName, name)
+	h.previews.mtx.Unlock()
+
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	h.log.Printf("deleted preview %s", name)
+	res.WriteHeader(http.StatusOK)
+}
+
+// Preview serves the API of a preview under /preview/{preview}, e.g.
+// /preview/1234/v1/chain/osmosis
+func (h *Handler) Preview(res http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)["preview"]
+	h.previews.mtx.RLock()
+	p, ok := h.previews.byName[name]
+	var loaded bool
+	var state string
+	if ok {
+		loaded, state = !p.loaded.IsZero(), p.state
+	}
+	h.previews.mtx.RUnlock()
+
+	switch {
+	case !ok:
+		resourceNotFound(res)
+		return
+	case !loaded && state == previewFailed:
+		badGateway(res)
+		return
+	case !loaded:
+		res.Header().Set("Retry-After", "10")
+		http.Error(res, "the preview is loading", http.StatusServiceUnavailable)
+		return
+	}
+	http.StripPrefix("/preview/"+name, p.router).ServeHTTP(res, req)
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func TestPreview(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	h := newTestHandler(rs.URL)
+	router := mux.NewRouter()
+	registerRoutes(router, h, options{})
+	router.HandleFunc("/admin/previews/{name}", h.CreatePreview).Methods("POST")
+
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/previews/1234", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf("creating the preview answered %d", res.Code)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		h.previews.mtx.RLock()
+		state := h.previews.byName["1234"].state
+		h.previews.mtx.RUnlock()
+		if state == previewReady {
+			break
+		}
+		if state == previewFailed || time.Now().After(deadline) {
+			t.Fatalf("preview is %s", state)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	res = httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/preview/1234/v1/chain/osmosis", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf("preview chain answered %d", res.Code)
+	}
+	if res.Header().Get("X-Registry-Updated") == "" {
+		t.Error("preview was served without the registry middleware")
+	}
+}
+
+func TestPreviewLimit(t *testing.T) {
+	h := newTestHandler("http://127.0.0.1:0")
+	router := mux.NewRouter()
+	router.HandleFunc("/admin/previews/{name}", h.CreatePreview).Methods("POST")
+
+	for i := 0; i < maxPreviews; i++ {
+		h.previews.byName[string(rune('a'+i))] = &preview{ref: "main"}
+	}
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/admin/previews/1234", nil))
+	if res.Code != http.StatusBadRequest {
+		t.Errorf("preview past the limit answered %d", res.Code)
+	}
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// DefaultPriceInterval is how often prices are refreshed by default
+	DefaultPriceInterval = 5 * time.Minute
+
+	defaultCoinGeckoUrl = "https://api.coingecko.com/api/v3"
+	// coinGeckoBatch bounds the number of ids requested at once
+	coinGeckoBatch = 100
+)
+
+// PriceProvider resolves coingecko ids to their current USD price. Ids without
+// a price are left out of the result.
+type PriceProvider interface {
+	Prices(ctx context.Context, ids []string) (map[string]float64, error)
+}
+
+// CoinGecko is a PriceProvider backed by CoinGecko's simple price API
+type CoinGecko struct {
+	// URL of the API. Defaults to https://api.coingecko.com/api/v3.
+	URL string
+	// APIKey is an optional demo API key, or a pro API key if URL is the pro
+	// API
+	APIKey string
+	// Client makes the requests. Defaults to a client shared by skychart.
+	Client *http.Client
+}
+
+var _ PriceProvider = CoinGecko{}
+
+func (c CoinGecko) Prices(ctx context.Context, ids []string) (map[string]float64, error) {
+	base := c.URL
+	if base == "" {
+		base = defaultCoinGeckoUrl
+	}
+	prices := make(map[string]float64, len(ids))
+	for start := 0; start < len(ids); start += coinGeckoBatch {
+		end := start + coinGeckoBatch
+		if end > len(ids) {
+			end = len(ids)
+		}
+		query := url.Values{"ids": {strings.Join(ids[start:end], ",")}, "vs_currencies": {"usd"}}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/simple/price?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if c.APIKey != "" {
+			if strings.Contains(base, "pro-api") {
+				req.Header.Set("x-cg-pro-api-key", c.APIKey)
+			} else {
+				req.Header.Set("x-cg-demo-api-key", c.APIKey)
+			}
+		}
+		resp, err := httpClient(c.Client).Do(req)
+		if err != nil {
+			return nil, err
+		}
+		bz, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code from coingecko: %d", resp.StatusCode)
+		}
+		var result map[string]map[string]float64
+		if err := json.Unmarshal(bz, &result); err != nil {
+			return nil, fmt.Errorf("unmarshalling coingecko prices: %w", err)
+		}
+		for id, price := range result {
+			if usd, ok := price["usd"]; ok {
+				prices[id] = usd
+			}
+		}
+	}
+	return prices, nil
+}
+
+// priceCache holds the prices of the registry's assets. It has its own lock
+// so that refreshing prices doesn't block reads of the registry.
+type priceCache struct {
+	mtx     sync.RWMutex
+	prices  map[string]float64 // coingecko id -> USD price
+	updated time.Time
+}
+
+func (c *priceCache) get(id string) (types.AssetPrice, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	usd, ok := c.prices[id]
+	return types.AssetPrice{USD: usd, Updated: c.updated}, ok
+}
+
+// updatePrices refreshes the prices of every asset with a coingecko id from
+// the provider every interval until the context is cancelled
+func (h *Handler) updatePrices(ctx context.Context, provider PriceProvider, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := h.refreshPrices(ctx, provider); err != nil && ctx.Err() == nil {
+			h.log.Printf("updating prices: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Handler) refreshPrices(ctx context.Context, provider PriceProvider) error {
+	h.mtx.RLock()
+	seen := make(map[string]bool)
+	ids := make([]string, 0)
+	for _, assetList := range h.assetList {
+		for _, asset := range assetList.Assets {
+			if asset.CoingeckoID != nil && *asset.CoingeckoID != "" && !seen[*asset.CoingeckoID] {
+				seen[*asset.CoingeckoID] = true
+				ids = append(ids, *asset.CoingeckoID)
+			}
+		}
+	}
+	h.mtx.RUnlock()
+
+	prices, err := provider.Prices(ctx, ids)
+	if err != nil {
+		return err
+	}
+	h.prices.mtx.Lock()
+	defer h.prices.mtx.Unlock()
+	h.prices.prices = prices
+	h.prices.updated = time.Now()
+	return nil
+}
+
+// includePrice reports whether the request asked for prices with
+// ?include=price
+func includePrice(req *http.Request) bool {
+	return includes(req, "price")
+}
+
+// includes reports whether the value is one of the comma separated values of
+// ?include=
+func includes(req *http.Request, value string) bool {
+	for _, include := range strings.Split(req.URL.Query().Get("include"), ",") {
+		if include == value {
+			return true
+		}
+	}
+	return false
+}
+
+// pricedAsset adds the current price to an asset, if it is known
+func (h *Handler) pricedAsset(asset types.AssetElement) types.PricedAsset {
+	priced := types.PricedAsset{AssetElement: asset}
+	if asset.CoingeckoID != nil {
+		if price, ok := h.prices.get(*asset.CoingeckoID); ok {
+			priced.Price = &price
+		}
+	}
+	return priced
+}
+
+func (h *Handler) pricedAssetList(assetList types.AssetList) types.PricedAssetList {
+	priced := types.PricedAssetList{ChainID: assetList.ChainID, Assets: make([]types.PricedAsset, 0, len(assetList.Assets))}
+	for _, asset := range assetList.Assets {
+		priced.Assets = append(priced.Assets, h.pricedAsset(asset))
+	}
+	return priced
+}
@@ -0,0 +1,235 @@
+package server
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/cmwaters/skychart/proto/skychartpb"
+	"github.com/cmwaters/skychart/types"
+)
+
+const protobufContentType = "application/x-protobuf"
+
+// marshalProto encodes the payload as the messages generated from
+// proto/skychart.proto. It returns false if the payload has no protobuf
+// representation, or can't be encoded, e.g. because of a string that isn't
+// valid UTF-8.
+func marshalProto(payload interface{}) ([]byte, bool) {
+	m := toProto(payload)
+	if m == nil {
+		return nil, false
+	}
+	// map entries are sorted so that the encoding, which is cached and hashed
+	// into the ETag, is stable
+	bz, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return nil, false
+	}
+	return bz, true
+}
+
+// toProto converts the payload into its protobuf message, or returns nil
+// if there is none
+func toProto(payload interface{}) proto.Message {
+	switch p := payload.(type) {
+	case types.Chain:
+		return chainProto(p)
+	case types.AssetList:
+		return assetListProto(p)
+	case types.AssetElement:
+		return assetProto(p)
+	case types.Staking:
+		return stakingProto(p)
+	case []string:
+		return &skychartpb.StringList{Values: p}
+	case []types.GrpcElement:
+		return &skychartpb.EndpointList{Endpoints: endpointsProto(p)}
+	case []types.PersistentPeerElement:
+		return &skychartpb.PeerList{Peers: peersProto(p)}
+	default:
+		return nil
+	}
+}
+
+func chainProto(chain types.Chain) *skychartpb.Chain {
+	m := &skychartpb.Chain{
+		ChainName:    chain.ChainName,
+		ChainId:      chain.ChainID,
+		PrettyName:   stringValue(chain.PrettyName),
+		Bech32Prefix: chain.Bech32Prefix,
+		DaemonName:   stringValue(chain.DaemonName),
+		NodeHome:     stringValue(chain.NodeHome),
+		Slip44:       chain.Slip44,
+	}
+	if chain.Status != nil {
+		m.Status = string(*chain.Status)
+	}
+	if chain.NetworkType != nil {
+		m.NetworkType = string(*chain.NetworkType)
+	}
+	if chain.Genesis != nil {
+		m.Genesis = &skychartpb.Genesis{GenesisUrl: stringValue(chain.Genesis.GenesisURL)}
+	}
+	for _, algo := range chain.KeyAlgos {
+		m.KeyAlgos = append(m.KeyAlgos, string(algo))
+	}
+	if chain.Fees != nil {
+		m.Fees = &skychartpb.Fees{}
+		for _, token := range chain.Fees.FeeTokens {
+			m.Fees.FeeTokens = append(m.Fees.FeeTokens, &skychartpb.FeeToken{
+				Denom:            token.Denom,
+				FixedMinGasPrice: token.FixedMinGasPrice,
+				LowGasPrice:      token.LowGasPrice,
+				AverageGasPrice:  token.AverageGasPrice,
+				HighGasPrice:     token.HighGasPrice,
+			})
+		}
+	}
+	if chain.Codebase != nil {
+		m.Codebase = &skychartpb.Codebase{
+			GitRepo:            chain.Codebase.GitRepo,
+			RecommendedVersion: chain.Codebase.RecommendedVersion,
+			CompatibleVersions: chain.Codebase.CompatibleVersions,
+		}
+		if chain.Codebase.Binaries != nil {
+			m.Codebase.Binaries = &skychartpb.Binaries{LinuxAmd: stringValue(chain.Codebase.Binaries.LinuxAMD)}
+		}
+		for _, version := range chain.Codebase.Versions {
+			m.Codebase.Versions = append(m.Codebase.Versions, &skychartpb.CodebaseVersion{
+				Name:               version.Name,
+				Height:             version.Height,
+				RecommendedVersion: version.RecommendedVersion,
+				Tag:                version.Tag,
+				NextVersionName:    version.NextVersionName,
+				Binaries:           version.Binaries,
+			})
+		}
+	}
+	if chain.Peers != nil {
+		m.Peers = &skychartpb.Peers{
+			Seeds:           peersProto(chain.Peers.Seeds),
+			PersistentPeers: peersProto(chain.Peers.PersistentPeers),
+		}
+	}
+	if chain.Apis != nil {
+		m.Apis = &skychartpb.Apis{
+			Rpc:  endpointsProto(chain.Apis.RPC),
+			Rest: endpointsProto(chain.Apis.REST),
+			Grpc: endpointsProto(chain.Apis.Grpc),
+		}
+	}
+	for _, explorer := range chain.Explorers {
+		m.Explorers = append(m.Explorers, &skychartpb.Explorer{
+			Kind:        stringValue(explorer.Kind),
+			Url:         stringValue(explorer.URL),
+			TxPage:      stringValue(explorer.TxPage),
+			AccountPage: stringValue(explorer.AccountPage),
+		})
+	}
+	if chain.LogoURIs != nil {
+		m.LogoUris = logoURIsProto(*chain.LogoURIs)
+	}
+	if chain.Staking != nil {
+		m.Staking = stakingProto(*chain.Staking)
+	}
+	return m
+}
+
+func stakingProto(staking types.Staking) *skychartpb.Staking {
+	m := &skychartpb.Staking{}
+	for _, token := range staking.StakingTokens {
+		m.StakingTokens = append(m.StakingTokens, &skychartpb.StakingToken{Denom: token.Denom})
+	}
+	if staking.LockDuration != nil {
+		m.LockDuration = &skychartpb.LockDuration{
+			Blocks: staking.LockDuration.Blocks,
+			Time:   stringValue(staking.LockDuration.Time),
+		}
+	}
+	return m
+}
+
+func assetListProto(assetList types.AssetList) *skychartpb.AssetList {
+	m := &skychartpb.AssetList{ChainId: assetList.ChainID}
+	for _, asset := range assetList.Assets {
+		m.Assets = append(m.Assets, assetProto(asset))
+	}
+	return m
+}
+
+func assetProto(asset types.AssetElement) *skychartpb.Asset {
+	m := &skychartpb.Asset{
+		Description: stringValue(asset.Description),
+		Base:        asset.Base,
+		Name:        stringValue(asset.Name),
+		Display:     asset.Display,
+		Symbol:      stringValue(asset.Symbol),
+		CoingeckoId: stringValue(asset.CoingeckoID),
+		Address:     stringValue(asset.Address),
+	}
+	for _, unit := range asset.DenomUnits {
+		m.DenomUnits = append(m.DenomUnits, &skychartpb.DenomUnit{
+			Denom:    unit.Denom,
+			Exponent: int64(unit.Exponent),
+			Aliases:  unit.Aliases,
+		})
+	}
+	if asset.LogoURIs != nil {
+		m.Logo_URIs = logoURIsProto(*asset.LogoURIs)
+	}
+	if asset.Ibc != nil {
+		m.Ibc = &skychartpb.IBC{
+			SourceChannel: asset.Ibc.SourceChannel,
+			DstChannel:    asset.Ibc.DstChannel,
+			SourceDenom:   asset.Ibc.SourceDenom,
+			SourceChain:   asset.Ibc.SourceChain,
+		}
+	}
+	if asset.Kind != nil {
+		m.Kind = string(*asset.Kind)
+	}
+	for _, trace := range asset.Traces {
+		t := &skychartpb.Trace{
+			Type: trace.Type,
+			Counterparty: &skychartpb.TraceCounterparty{
+				ChainName: trace.Counterparty.ChainName,
+				BaseDenom: trace.Counterparty.BaseDenom,
+				ChannelId: trace.Counterparty.ChannelID,
+			},
+			Provider: trace.Provider,
+		}
+		if trace.Chain != nil {
+			t.Chain = &skychartpb.TraceChain{ChannelId: trace.Chain.ChannelID, Path: trace.Chain.Path}
+		}
+		m.Traces = append(m.Traces, t)
+	}
+	return m
+}
+
+func logoURIsProto(logos types.LogoURIs) *skychartpb.LogoURIs {
+	return &skychartpb.LogoURIs{Png: stringValue(logos.PNG), Svg: stringValue(logos.SVG)}
+}
+
+func endpointsProto(endpoints []types.GrpcElement) []*skychartpb.Endpoint {
+	var m []*skychartpb.Endpoint
+	for _, endpoint := range endpoints {
+		m = append(m, &skychartpb.Endpoint{Address: endpoint.Address, Provider: stringValue(endpoint.Provider)})
+	}
+	return m
+}
+
+func peersProto(peers []types.PersistentPeerElement) []*skychartpb.Peer {
+	var m []*skychartpb.Peer
+	for _, peer := range peers {
+		m = append(m, &skychartpb.Peer{Id: peer.ID, Address: peer.Address, Provider: stringValue(peer.Provider)})
+	}
+	return m
+}
+
+// stringValue returns the string v points to, or an empty string, which
+// proto3 omits, if it is nil
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
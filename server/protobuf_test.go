@@ -0,0 +1,95 @@
+package server
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/cmwaters/skychart/proto/skychartpb"
+	"github.com/cmwaters/skychart/types"
+)
+
+func TestMarshalProtoChain(t *testing.T) {
+	pretty, provider, slip44 := "Osmosis", "Osmosis Foundation", 118.0
+	chain := types.Chain{
+		ChainName:  "osmosis",
+		ChainID:    "osmosis-1",
+		PrettyName: &pretty,
+		KeyAlgos:   []types.KeyAlgo{"secp256k1", "ethsecp256k1"},
+		Slip44:     &slip44,
+		Apis:       &types.Apis{RPC: []types.GrpcElement{{Address: "https://rpc.osmosis.zone", Provider: &provider}}},
+	}
+	b, ok := marshalProto(chain)
+	if !ok {
+		t.Fatal("chain has no protobuf encoding")
+	}
+	var decoded skychartpb.Chain
+	if err := proto.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ChainName != "osmosis" || decoded.ChainId != "osmosis-1" || decoded.PrettyName != "Osmosis" {
+		t.Errorf("chain %v", &decoded)
+	}
+	if algos := decoded.KeyAlgos; len(algos) != 2 || algos[0] != "secp256k1" || algos[1] != "ethsecp256k1" {
+		t.Errorf("key algos %q", algos)
+	}
+	if decoded.Slip44 == nil || *decoded.Slip44 != 118 {
+		t.Errorf("slip44 %v", decoded.Slip44)
+	}
+	if decoded.Fees != nil || decoded.Genesis != nil {
+		t.Errorf("unset sections are encoded: %v", &decoded)
+	}
+	rpc := decoded.GetApis().GetRpc()
+	if len(rpc) != 1 || rpc[0].Address != "https://rpc.osmosis.zone" || rpc[0].Provider != provider {
+		t.Errorf("rpc endpoints %v", rpc)
+	}
+}
+
+func TestMarshalProtoAsset(t *testing.T) {
+	asset := types.AssetElement{
+		Base:       "ibc/27394FB0",
+		Display:    "atom",
+		DenomUnits: []types.DenomUnitElement{{Denom: "ibc/27394FB0"}, {Denom: "atom", Exponent: 6}},
+		// the counterparty is required by the registry, so it is encoded
+		// even when empty
+		Traces: []types.Trace{{Type: "ibc"}},
+	}
+	b, ok := marshalProto(types.AssetList{ChainID: "osmosis-1", Assets: []types.AssetElement{asset}})
+	if !ok {
+		t.Fatal("asset list has no protobuf encoding")
+	}
+	var decoded skychartpb.AssetList
+	if err := proto.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ChainId != "osmosis-1" || len(decoded.Assets) != 1 {
+		t.Fatalf("asset list %v", &decoded)
+	}
+	got := decoded.Assets[0]
+	if got.Base != asset.Base || got.Display != asset.Display {
+		t.Errorf("asset %v", got)
+	}
+	if units := got.DenomUnits; len(units) != 2 || units[1].Denom != "atom" || units[1].Exponent != 6 {
+		t.Errorf("denom units %v", units)
+	}
+	if len(got.Traces) != 1 || got.Traces[0].Counterparty == nil {
+		t.Errorf("traces %v", got.Traces)
+	}
+}
+
+func TestMarshalProtoLists(t *testing.T) {
+	b, ok := marshalProto([]string{"cosmoshub", "osmosis"})
+	if !ok {
+		t.Fatal("string list has no protobuf encoding")
+	}
+	var list skychartpb.StringList
+	if err := proto.Unmarshal(b, &list); err != nil {
+		t.Fatal(err)
+	}
+	if values := list.Values; len(values) != 2 || values[0] != "cosmoshub" || values[1] != "osmosis" {
+		t.Errorf("values %q", values)
+	}
+	if _, ok := marshalProto(map[string]string{}); ok {
+		t.Error("maps have no protobuf encoding")
+	}
+}
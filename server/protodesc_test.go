@@ -0,0 +1,239 @@
+package server
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// protoScalars maps the scalar types used by proto/skychart.proto to their
+// descriptor types
+var protoScalars = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"string": descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	"double": descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	"int64":  descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	"bool":   descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+}
+
+var (
+	protoComment = regexp.MustCompile(`//[^\n]*`)
+	protoMessage = regexp.MustCompile(`message\s+(\w+)\s*\{([^}]*)\}`)
+	protoField   = regexp.MustCompile(`^(repeated\s+|optional\s+)?(map\s*<\s*(\w+)\s*,\s*(\w+)\s*>|\w+)\s+(\w+)\s*=\s*(\d+)$`)
+)
+
+// parseProto builds the descriptor of proto/skychart.proto. It only supports
+// what the file uses: top level messages with scalar, message, repeated,
+// optional and map fields.
+func parseProto(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	bz, err := os.ReadFile("../proto/skychart.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := protoComment.ReplaceAllString(string(bz), "")
+	pkg := regexp.MustCompile(`package\s+([\w.]+)\s*;`).FindStringSubmatch(src)[1]
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("skychart.proto"),
+		Package: proto.String(pkg),
+		Syntax:  proto.String("proto3"),
+	}
+	fieldType := func(field *descriptorpb.FieldDescriptorProto, typ string) {
+		if scalar, ok := protoScalars[typ]; ok {
+			field.Type = scalar.Enum()
+			return
+		}
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		field.TypeName = proto.String("." + pkg + "." + typ)
+	}
+	for _, m := range protoMessage.FindAllStringSubmatch(src, -1) {
+		message := &descriptorpb.DescriptorProto{Name: proto.String(m[1])}
+		for _, line := range strings.Split(m[2], ";") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			f := protoField.FindStringSubmatch(line)
+			if f == nil {
+				t.Fatalf("unsupported field in message %s: %s", m[1], line)
+			}
+			number, _ := strconv.Atoi(f[6])
+			field := &descriptorpb.FieldDescriptorProto{
+				Name:   proto.String(f[5]),
+				Number: proto.Int32(int32(number)),
+				Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			}
+			switch {
+			case f[3] != "":
+				// maps are repeated entries of a nested message
+				entry := &descriptorpb.DescriptorProto{
+					Name:    proto.String(strings.ToUpper(f[5][:1]) + f[5][1:] + "Entry"),
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}
+				for i, kv := range []string{f[3], f[4]} {
+					entryField := &descriptorpb.FieldDescriptorProto{
+						Name:   proto.String([]string{"key", "value"}[i]),
+						Number: proto.Int32(int32(i + 1)),
+						Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					}
+					fieldType(entryField, kv)
+					entry.Field = append(entry.Field, entryField)
+				}
+				message.NestedType = append(message.NestedType, entry)
+				field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+				field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+				field.TypeName = proto.String("." + pkg + "." + m[1] + "." + entry.GetName())
+			case strings.TrimSpace(f[1]) == "repeated":
+				field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+				fieldType(field, f[2])
+			case strings.TrimSpace(f[1]) == "optional":
+				// proto3 optional fields are in a synthetic oneof of their own
+				field.Proto3Optional = proto.Bool(true)
+				field.OneofIndex = proto.Int32(int32(len(message.OneofDecl)))
+				message.OneofDecl = append(message.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + f[5])})
+				fieldType(field, f[2])
+			default:
+				fieldType(field, f[2])
+			}
+			message.Field = append(message.Field, field)
+		}
+		file.MessageType = append(file.MessageType, message)
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		t.Fatalf("invalid descriptor of skychart.proto: %v", err)
+	}
+	return fd
+}
+
+// checkKnown fails the test if the message or any message it holds has
+// fields that the descriptor doesn't know about
+func checkKnown(t *testing.T, path string, m protoreflect.Message) {
+	t.Helper()
+	if unknown := m.GetUnknown(); len(unknown) > 0 {
+		t.Errorf("%s has fields missing from skychart.proto: %x", path, unknown)
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		if fd.IsList() {
+			for i := 0; i < v.List().Len(); i++ {
+				checkKnown(t, path+"."+string(fd.Name()), v.List().Get(i).Message())
+			}
+			return true
+		}
+		checkKnown(t, path+"."+string(fd.Name()), v.Message())
+		return true
+	})
+}
+
+// TestProtoDescriptor checks that the generated types stay in sync with
+// proto/skychart.proto, by decoding fully populated payloads with the
+// descriptor of the file and encoding them again
+func TestProtoDescriptor(t *testing.T) {
+	fd := parseProto(t)
+	s := func(v string) *string { return &v }
+	f := func(v float64) *float64 { return &v }
+	status, network, kind := types.Status("live"), types.NetworkType("mainnet"), types.Ics20
+	logos := &types.LogoURIs{PNG: s("https://example.com/osmo.png"), SVG: s("https://example.com/osmo.svg")}
+	endpoint := types.GrpcElement{Address: "https://rpc.osmosis.zone", Provider: s("Osmosis")}
+	peer := types.PersistentPeerElement{ID: "abc", Address: "1.2.3.4:26656", Provider: s("Osmosis")}
+	chain := types.Chain{
+		Apis:         &types.Apis{Grpc: []types.GrpcElement{endpoint}, REST: []types.GrpcElement{endpoint}, RPC: []types.GrpcElement{endpoint}},
+		Bech32Prefix: "osmo",
+		ChainID:      "osmosis-1",
+		ChainName:    "osmosis",
+		Codebase: &types.Codebase{
+			Binaries:           &types.Binaries{LinuxAMD: s("https://example.com/osmosisd")},
+			CompatibleVersions: []string{"v15.0.0", "v15.1.0"},
+			GitRepo:            "https://github.com/osmosis-labs/osmosis",
+			RecommendedVersion: "v15.1.0",
+			Versions: []types.CodebaseVersion{{
+				Binaries:           map[string]string{"linux/amd64": "https://example.com/a", "darwin/arm64": "https://example.com/b"},
+				Height:             f(1000),
+				Name:               "v15",
+				NextVersionName:    "v16",
+				RecommendedVersion: "v15.1.0",
+				Tag:                "v15.1.0",
+			}},
+		},
+		DaemonName:  s("osmosisd"),
+		Explorers:   []types.ExplorerElement{{AccountPage: s("/account/${accountAddress}"), Kind: s("mintscan"), TxPage: s("/tx/${txHash}"), URL: s("https://mintscan.io")}},
+		Fees:        &types.Fees{FeeTokens: []types.FeeTokenElement{{Denom: "uosmo", FixedMinGasPrice: f(0), LowGasPrice: f(0.0025), AverageGasPrice: f(0.025), HighGasPrice: f(0.04)}}},
+		Genesis:     &types.Genesis{GenesisURL: s("https://example.com/genesis.json")},
+		KeyAlgos:    []types.KeyAlgo{"secp256k1"},
+		LogoURIs:    logos,
+		NetworkType: &network,
+		NodeHome:    s("$HOME/.osmosisd"),
+		Peers:       &types.Peers{PersistentPeers: []types.PersistentPeerElement{peer}, Seeds: []types.PersistentPeerElement{peer}},
+		PrettyName:  s("Osmosis"),
+		Slip44:      f(118),
+		Staking:     &types.Staking{LockDuration: &types.LockDuration{Blocks: f(10), Time: s("1209600s")}, StakingTokens: []types.StakingTokenElement{{Denom: "uosmo"}}},
+		Status:      &status,
+	}
+	asset := types.AssetElement{
+		Address:     s("osmo1contract"),
+		Base:        "ibc/27394FB0",
+		CoingeckoID: s("cosmos"),
+		DenomUnits:  []types.DenomUnitElement{{Aliases: []string{"microatom"}, Denom: "ibc/27394FB0"}, {Denom: "atom", Exponent: 6}},
+		Description: s("The native staking token of the Cosmos Hub"),
+		Display:     "atom",
+		Ibc:         &types.Ibc{DstChannel: "channel-0", SourceChannel: "channel-141", SourceDenom: "uatom", SourceChain: "cosmoshub"},
+		Kind:        &kind,
+		LogoURIs:    logos,
+		Name:        s("Cosmos Hub Atom"),
+		Symbol:      s("ATOM"),
+		Traces: []types.Trace{{
+			Type:         "ibc",
+			Counterparty: types.TraceCounterparty{ChainName: "cosmoshub", BaseDenom: "uatom", ChannelID: "channel-141"},
+			Chain:        &types.TraceChain{ChannelID: "channel-0", Path: "transfer/channel-0/uatom"},
+			Provider:     "Osmosis",
+		}},
+	}
+
+	tests := []struct {
+		message protoreflect.Name
+		payload interface{}
+	}{
+		{"Chain", chain},
+		{"AssetList", types.AssetList{ChainID: "osmosis-1", Assets: []types.AssetElement{asset}}},
+		{"Asset", asset},
+		{"Staking", *chain.Staking},
+		{"StringList", []string{"cosmoshub", "osmosis"}},
+		{"EndpointList", []types.GrpcElement{endpoint}},
+		{"PeerList", []types.PersistentPeerElement{peer}},
+	}
+	for _, tc := range tests {
+		desc := fd.Messages().ByName(tc.message)
+		if desc == nil {
+			t.Fatalf("skychart.proto has no message %s", tc.message)
+		}
+		b, ok := marshalProto(tc.payload)
+		if !ok {
+			t.Fatalf("%s has no protobuf encoding", tc.message)
+		}
+		m := dynamicpb.NewMessage(desc)
+		if err := proto.Unmarshal(b, m); err != nil {
+			t.Fatalf("%s doesn't decode with skychart.proto: %v", tc.message, err)
+		}
+		checkKnown(t, string(tc.message), m)
+		again, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, again) {
+			t.Errorf("%s encodes differently from skychart.proto:\n%x\n%x", tc.message, b, again)
+		}
+	}
+}
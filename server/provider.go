@@ -0,0 +1,193 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// endpointKinds are the kinds of endpoint a chain lists, as named by
+// /chain/{chain}/endpoints/{type}
+var endpointKinds = []string{"rpc", "rest", "grpc", "peers", "seeds"}
+
+// endpoint is an endpoint of a chain and its provider, if listed
+type endpoint struct {
+	address  string
+	provider string
+}
+
+// chainEndpoints returns the endpoints the chain lists, keyed by their kind.
+// Peers and seeds are addressed as id@host:port.
+func chainEndpoints(chain types.Chain) map[string][]endpoint {
+	endpoints := make(map[string][]endpoint)
+	if chain.Apis != nil {
+		for kind, apis := range map[string][]types.GrpcElement{"rpc": chain.Apis.RPC, "rest": chain.Apis.REST, "grpc": chain.Apis.Grpc} {
+			for _, api := range apis {
+				endpoints[kind] = append(endpoints[kind], endpoint{address: api.Address, provider: providerName(api.Provider)})
+			}
+		}
+	}
+	if chain.Peers != nil {
+		for kind, peers := range map[string][]types.PersistentPeerElement{"peers": chain.Peers.PersistentPeers, "seeds": chain.Peers.Seeds} {
+			for _, peer := range peers {
+				endpoints[kind] = append(endpoints[kind], endpoint{address: peer.ID + "@" + peer.Address, provider: providerName(peer.Provider)})
+			}
+		}
+	}
+	return endpoints
+}
+
+// providerName returns the provider as listed, without surrounding whitespace
+func providerName(provider *string) string {
+	if provider == nil {
+		return ""
+	}
+	return strings.TrimSpace(*provider)
+}
+
+// providerSet parses a comma separated list of providers into a set of their
+// lower case names, or nil if the list is empty
+func providerSet(list string) map[string]bool {
+	var set map[string]bool
+	for _, provider := range strings.Split(list, ",") {
+		provider = strings.ToLower(strings.TrimSpace(provider))
+		if provider == "" {
+			continue
+		}
+		if set == nil {
+			set = make(map[string]bool)
+		}
+		set[provider] = true
+	}
+	return set
+}
+
+// providerFilter returns whether to keep an endpoint run by a provider, given
+// the comma separated providers to keep in ?provider= and those to leave out
+// in ?exclude_provider=, compared case-insensitively. It returns false if the
+// request filters by neither.
+func providerFilter(req *http.Request) (func(provider *string) bool, bool) {
+	include := providerSet(req.URL.Query().Get("provider"))
+	exclude := providerSet(req.URL.Query().Get("exclude_provider"))
+	if include == nil && exclude == nil {
+		return nil, false
+	}
+	return func(provider *string) bool {
+		name := strings.ToLower(providerName(provider))
+		if exclude[name] {
+			return false
+		}
+		return include == nil || include[name]
+	}, true
+}
+
+// filterEndpoints returns the apis or peers that keep accepts
+func filterEndpoints(endpoints interface{}, keep func(provider *string) bool) interface{} {
+	switch endpoints := endpoints.(type) {
+	case []types.GrpcElement:
+		return filterAPIs(endpoints, keep)
+	case []types.PersistentPeerElement:
+		return filterPeers(endpoints, keep)
+	}
+	return endpoints
+}
+
+// filterAPIs returns the apis that keep accepts
+func filterAPIs(apis []types.GrpcElement, keep func(provider *string) bool) []types.GrpcElement {
+	filtered := make([]types.GrpcElement, 0, len(apis))
+	for _, api := range apis {
+		if keep(api.Provider) {
+			filtered = append(filtered, api)
+		}
+	}
+	return filtered
+}
+
+// filterPeers returns the peers that keep accepts
+func filterPeers(peers []types.PersistentPeerElement, keep func(provider *string) bool) []types.PersistentPeerElement {
+	filtered := make([]types.PersistentPeerElement, 0, len(peers))
+	for _, peer := range peers {
+		if keep(peer.Provider) {
+			filtered = append(filtered, peer)
+		}
+	}
+	return filtered
+}
+
+// providerStats aggregates the endpoints of each provider, keyed by the lower
+// case name of the provider. Names that differ only in case are counted as one
+// provider, spelled as by the first chain that lists it. The caller must hold
+// the read lock.
+func (h *Handler) providerStats() map[string]*types.ProviderStats {
+	stats := make(map[string]*types.ProviderStats)
+	for _, name := range h.chains {
+		endpoints := chainEndpoints(h.chainList[name])
+		served := make(map[string]bool)
+		for _, kind := range endpointKinds {
+			servedKind := make(map[string]bool)
+			for _, endpoint := range endpoints[kind] {
+				provider := endpoint.provider
+				if provider == "" {
+					continue
+				}
+				key := strings.ToLower(provider)
+				stat, ok := stats[key]
+				if !ok {
+					stat = &types.ProviderStats{
+						Provider:         provider,
+						ChainsByEndpoint: make(map[string]int),
+						Endpoints:        make(map[string]int),
+					}
+					stats[key] = stat
+				}
+				stat.Endpoints[kind]++
+				if !servedKind[key] {
+					servedKind[key] = true
+					stat.ChainsByEndpoint[kind]++
+				}
+				if !served[key] {
+					served[key] = true
+					stat.Chains++
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// Providers returns the name of every provider that runs an endpoint of any
+// chain
+func (h *Handler) Providers(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	providers := []string{}
+	for _, stat := range h.providerStats() {
+		providers = append(providers, stat.Provider)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return strings.ToLower(providers[i]) < strings.ToLower(providers[j])
+	})
+	h.respond(res, req, providers)
+}
+
+// ProviderStats returns how many chains and endpoints of each kind every
+// provider runs, the providers of the most chains first
+func (h *Handler) ProviderStats(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	stats := []types.ProviderStats{}
+	for _, stat := range h.providerStats() {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Chains != stats[j].Chains {
+			return stats[i].Chains > stats[j].Chains
+		}
+		return strings.ToLower(stats[i].Provider) < strings.ToLower(stats[j].Provider)
+	})
+	h.respond(res, req, stats)
+}
@@ -1,201 +1,319 @@
 package server
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/cmwaters/skychart/types"
 )
 
-// Pull requests all registry information from a github repo and updates the
-// handlers local registry. It expects a directory structure as follows:
+// Pull requests all registry information from the registry's source, by
+// default a github repo, and updates the handlers local registry. It expects
+// a directory structure as follows:
 // - [chain_name]
 //   - chain.json
 //   - assetlist.json
 // It works on a best effort basis. All chain names should be unique. chain.json and
-// assetlist.json should comply with the respective schemas
+// assetlist.json should comply with the respective schemas. The registry is only
+// updated once every file has been fetched, so if a pull fails the handler
+// continues to serve the last complete registry. Pulls never overlap: if a
+// pull is already running, Pull waits for it and returns its result.
 // TODO: Add support for relayer paths
 func (h *Handler) Pull(ctx context.Context) error {
-	// If there have been no recent commits we can return immediately
-	recent, err := h.recentCommits()
-	if err != nil {
-		return err
-	}
-	if !recent {
-		h.log.Printf("no new recent commits since %s", h.lastUpdated.String())
-		h.lastUpdated = time.Now()
-		return nil
+	h.pullMtx.Lock()
+	if call := h.pulling; call != nil {
+		h.pullMtx.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
+	call := &pullCall{done: make(chan struct{})}
+	h.pulling = call
+	h.pullMtx.Unlock()
 
-	// update chains
-	if err := h.getChains(); err != nil {
-		return err
+	start := time.Now()
+	err := h.pull(ctx)
+	if err == nil {
+		h.pruneGenesis()
 	}
-
-	// for each chain update the chain info and asset list
-	// TODO: If we wanted to be more creative we could first check
-	// to see if the file had actually changed since the last time
-	// it was pulled
-	for _, chain := range h.chains {
-		if err := h.getChain(chain); err != nil {
-			return err
-		}
-		if err := h.getAssetList(chain); err != nil {
-			return err
+	// the mirror trails the registry rather than failing the pull
+	if err == nil && h.mirror != nil {
+		h.mtx.RLock()
+		source, commit := h.registrySource(), h.commit
+		h.mtx.RUnlock()
+		if err := h.updateMirror(ctx, source, commit); err != nil {
+			h.log.Printf("failed to update mirror: %s", err)
 		}
 	}
 
-	// Index assets by display
-	assets := make([]string, 0)
-	for _, assetList := range h.assetList {
-		name := h.chainById[assetList.ChainID]
-		for _, asset := range assetList.Assets {
-			assets = append(assets, asset.Display)
-			h.chainByAsset[asset.Display] = name
-		}
-	}
+	h.mtx.Lock()
+	h.lastAttempt = start
+	h.lastPullDuration = time.Since(start)
+	h.lastErr = err
+	h.mtx.Unlock()
 
-	// update timestamp
-	h.lastUpdated = time.Now()
-	h.log.Printf("successfully updated registry (%d chains)", len(h.chains))
+	call.err = err
+	h.pullMtx.Lock()
+	h.pulling = nil
+	h.pullMtx.Unlock()
+	close(call.done)
+	return err
+}
 
-	return nil
+// pullCall is a pull in progress that other callers of Pull wait for
+type pullCall struct {
+	done chan struct{}
+	err  error
 }
 
-func (h *Handler) getChains() error {
-	query := fmt.Sprintf("https://api.github.com/repos/%s/contents", h.registryUrl)
-	resp, err := http.Get(query)
+func (h *Handler) pull(ctx context.Context) error {
+	h.mtx.RLock()
+	source, lastUpdated, filter, refilter := h.registrySource(), h.lastUpdated, h.filter, h.refilter
+	h.mtx.RUnlock()
+
+	// If there have been no recent commits we can return immediately,
+	// unless the chains that are pulled have changed
+	recent, err := source.Changed(ctx, lastUpdated)
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code from query %s: %d", query, resp.StatusCode)
+	if !recent && !refilter {
+		// checksums that expired since the last pull are looked up again
+		h.mtx.RLock()
+		chains := h.chainList
+		h.mtx.RUnlock()
+		h.refreshChecksums(ctx, chains)
+
+		h.mtx.Lock()
+		h.log.Printf("no new recent commits since %s", h.lastUpdated.String())
+		h.lastUpdated = time.Now()
+		h.cache.reset()
+		h.mtx.Unlock()
+		return nil
+	}
+
+	// the commit is fetched before the files, which are then read at the
+	// commit where the source allows it, so that the files match the commit
+	// reported with them even if the branch moves during the pull
+	var commit string
+	if versioned, ok := source.(commitSource); ok {
+		commit, err = versioned.Commit(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if pinnable, ok := source.(pinnableSource); ok && commit != "" {
+		source = pinnable.At(commit)
 	}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	// update chains
+	names, err := source.Chains(ctx)
 	if err != nil {
 		return err
 	}
-
-	var repo []map[string]interface{}
-	if err := json.Unmarshal(bodyBytes, &repo); err != nil {
-		return fmt.Errorf("unmarshalling repo: %w", err)
+	upstream := set(names)
+	names = filter.apply(names)
+	if batched, ok := source.(prefetchSource); ok {
+		if err := batched.Prefetch(ctx, names); err != nil {
+			return err
+		}
 	}
-
-	chains := make([]string, 0)
-	for _, entry := range repo {
-		// only accept directories
-		entryType := entry["type"].(string)
-		if entryType != "dir" {
-			continue
+	// files that earlier pulls found missing are only requested again once
+	// the listing shows them
+	if err := h.missing.refresh(ctx, source); err != nil {
+		if abortPull(ctx, err) {
+			return err
 		}
+		h.log.Printf("listing the files of the registry: %v", err)
+	}
 
-		name := entry["name"].(string)
-		if strings.Contains(name, "testnets") {
+	// for each chain update the chain info and asset list
+	// TODO: If we wanted to be more creative we could first check
+	// to see if the file had actually changed since the last time
+	// it was pulled
+	chains := make(map[string]types.Chain, len(names))
+	assetLists := make(map[string]types.AssetList, len(names))
+	files := make(map[string][]byte, 2*len(names))
+	chainErrors := make(map[string]string)
+	schemas := newSchemaReport()
+	for _, name := range names {
+		chain, chainOk, err := h.getChain(ctx, source, name, schemas, files)
+		if err != nil {
+			if abortPull(ctx, err) {
+				return err
+			}
+			chainErrors[name] = err.Error()
 			continue
 		}
-		if strings.Contains(name, ".") {
+		assetList, assetListOk, err := h.getAssetList(ctx, source, name, schemas, files)
+		if err != nil {
+			if abortPull(ctx, err) {
+				return err
+			}
+			chainErrors[name] = err.Error()
 			continue
 		}
+		if chainOk {
+			chains[name] = chain
+		}
+		if assetListOk {
+			assetLists[name] = assetList
+		}
+	}
 
-		chains = append(chains, name)
+	failedSchemas := h.getSchemas(ctx, source, commit, files)
+	// the checksums are looked up before the registry is updated so that
+	// genesis-info serves them along with the chains
+	h.refreshChecksums(ctx, chains)
 
-		fmt.Printf("%s, ", name)
-	}
-	fmt.Printf("\n")
-	h.chains = chains
-	fmt.Print(len(h.chains))
-	return nil
-}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
 
-func (h *Handler) getChain(name string) error {
-	query := fmt.Sprintf("https://raw.githubusercontent.com/%s/master/%s/chain.json", h.registryUrl, name)
-	resp, err := http.Get(query)
-	if err != nil {
-		return err
+	// replace the lists entirely so that chains and asset lists that were
+	// deleted upstream are dropped, keeping the current lists to record what
+	// this pull changes
+	prevChains, prevAssetLists, prevUpdated := h.chainList, h.assetList, h.lastUpdated
+	// chains that failed to be fetched keep their previous version
+	for name, err := range chainErrors {
+		h.log.Printf("failed to update chain %s: %s", name, err)
+		if chain, ok := prevChains[name]; ok {
+			chains[name] = chain
+		}
+		if assetList, ok := prevAssetLists[name]; ok {
+			assetLists[name] = assetList
+		}
+		for _, file := range []string{name + "/chain.json", name + "/assetlist.json"} {
+			if version, ok := h.schemas.versions[file]; ok {
+				schemas.record(file, version, h.schemas.unknown[file])
+			}
+			if bz, ok := h.files[file]; ok {
+				files[file] = bz
+			}
+		}
 	}
-
-	// If the chain.json file doesn't exist we simply ignore it
-	if resp.StatusCode == http.StatusNotFound {
-		return nil
+	for _, schema := range failedSchemas {
+		if bz, ok := h.files[schema]; ok {
+			files[schema] = bz
+		}
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code from query %s: %d", query, resp.StatusCode)
+	h.recordTombstones(prevChains, prevAssetLists, upstream, time.Now())
+	h.chainList = chains
+	h.assetList = assetLists
+	h.files = files
+	h.fileHashes = hashFiles(files)
+	h.chainErrors = chainErrors
+	h.schemas = schemas
+	h.commit = commit
+	if refilter {
+		h.refilter = false
 	}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
+	// rebuild the indexes from scratch so that entries from previous pulls
+	// don't linger
+	h.index()
 
-	var chain types.Chain
-	err = json.Unmarshal(bodyBytes, &chain)
-	if err != nil {
-		return err
+	// update timestamp
+	h.lastUpdated = time.Now()
+	h.cache.reset()
+	if h.recordChanges(prevChains, prevAssetLists, h.lastUpdated) > 0 {
+		h.notify(h.changesSince(prevUpdated))
 	}
+	h.recordHistory(prevChains, prevAssetLists, h.lastUpdated)
+	h.log.Printf("successfully updated registry (%d chains)", len(h.chains))
 
-	h.chainList[name] = chain
-	h.chainById[chain.ChainID] = name
 	return nil
 }
 
-func (h *Handler) getAssetList(name string) error {
-	query := fmt.Sprintf("https://raw.githubusercontent.com/%s/master/%s/assetlist.json", h.registryUrl, name)
-	resp, err := http.Get(query)
-	if err != nil {
-		return err
-	}
-
-	// If the chain.json file doesn't exist we simply ignore it
-	if resp.StatusCode == http.StatusNotFound {
-		return nil
-	}
+// abortPull returns true if the error fetching a chain should fail the whole
+// pull rather than only the chain, because the remaining chains would fail
+// in the same way
+func abortPull(ctx context.Context, err error) bool {
+	var rateLimitErr *RateLimitError
+	return ctx.Err() != nil || errors.As(err, &rateLimitErr)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code for query %s: %d", query, resp.StatusCode)
+// getChain fetches the chain.json of the chain, records its schema in the
+// report and the file as pulled in files. It returns false if the chain has
+// no chain.json.
+func (h *Handler) getChain(ctx context.Context, source Source, name string, schemas *schemaReport, files map[string][]byte) (types.Chain, bool, error) {
+	r, ok, err := h.openChainFile(ctx, source, name, "chain.json")
+	if err != nil || !ok {
+		return types.Chain{}, false, err
 	}
-
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	defer r.Close()
+	var file bytes.Buffer
+	chain, version, err := decodeChain(io.TeeReader(r, &file))
 	if err != nil {
-		return err
+		return chain, false, fmt.Errorf("unmarshalling %s/chain.json: %w", name, err)
 	}
-
-	var assetList types.AssetList
-	err = json.Unmarshal(bodyBytes, &assetList)
-	if err != nil {
-		return err
+	// the decoder stops reading short of any trailing whitespace
+	if _, err := io.Copy(&file, r); err != nil {
+		return chain, false, fmt.Errorf("reading %s/chain.json: %w", name, err)
 	}
-
-	h.assetList[name] = assetList
-	return nil
+	schemas.record(name+"/chain.json", version, unknownChainFields(file.Bytes()))
+	files[name+"/chain.json"] = file.Bytes()
+	return chain, true, nil
 }
 
-// recentCommits returns true if there has been a commit more recent than the time the handler
-// last updated
-func (h Handler) recentCommits() (bool, error) {
-	lastUpdated := h.lastUpdated.Format(time.RFC3339)
-	query := fmt.Sprintf("https://api.github.com/repos/%s/commits?since=%s", h.registryUrl, lastUpdated)
-	resp, err := http.Get(query)
+// getAssetList fetches the assetlist.json of the chain, records its schema in
+// the report and the file as pulled in files. It returns false if the chain
+// has no assetlist.json.
+func (h *Handler) getAssetList(ctx context.Context, source Source, name string, schemas *schemaReport, files map[string][]byte) (types.AssetList, bool, error) {
+	r, ok, err := h.openChainFile(ctx, source, name, "assetlist.json")
+	if err != nil || !ok {
+		return types.AssetList{}, false, err
+	}
+	defer r.Close()
+	var file bytes.Buffer
+	assetList, version, err := decodeAssetList(io.TeeReader(r, &file))
 	if err != nil {
-		return false, err
+		return assetList, false, fmt.Errorf("unmarshalling %s/assetlist.json: %w", name, err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("unexpected status code for query %s: %d", query, resp.StatusCode)
+	// the decoder stops reading short of any trailing whitespace
+	if _, err := io.Copy(&file, r); err != nil {
+		return assetList, false, fmt.Errorf("reading %s/assetlist.json: %w", name, err)
 	}
+	schemas.record(name+"/assetlist.json", version, unknownAssetListFields(file.Bytes()))
+	files[name+"/assetlist.json"] = file.Bytes()
+	return assetList, true, nil
+}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+// RateLimitError is returned when github rejects a request because the rate
+// limit has been exceeded. Reset is when the quota is replenished, or zero if
+// github didn't say.
+type RateLimitError struct {
+	Query string
+	Reset time.Time
+}
 
-	var body []interface{}
-	err = json.Unmarshal(bodyBytes, &body)
-	if err != nil {
-		return false, err
+func (e *RateLimitError) Error() string {
+	if e.Reset.IsZero() {
+		return fmt.Sprintf("rate limited by query %s", e.Query)
 	}
+	return fmt.Sprintf("rate limited by query %s until %s", e.Query, e.Reset.Format(time.RFC3339))
+}
 
-	return len(body) > 0, nil
+// rateLimited returns a RateLimitError if the response indicates the github
+// rate limit has been exceeded
+func rateLimited(resp *http.Response) error {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	if resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return nil
+	}
+	err := &RateLimitError{Query: resp.Request.URL.String()}
+	if reset, parseErr := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); parseErr == nil {
+		err.Reset = time.Unix(reset, 0)
+	}
+	return err
 }
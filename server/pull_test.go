@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cmwaters/skychart/testutil"
+	"github.com/cmwaters/skychart/types"
+)
+
+// newTestHandler returns a handler pulling from the registry server at url
+func newTestHandler(url string) *Handler {
+	h := NewHandler("cosmos/chain-registry", log.New(io.Discard, "", 0))
+	h.SetUpstream(url, url)
+	return h
+}
+
+func newTestRegistry() *testutil.RegistryServer {
+	rs := testutil.NewRegistryServer("cosmos/chain-registry")
+	rs.SetChain("osmosis", types.Chain{ChainName: "osmosis", ChainID: "osmosis-1", Bech32Prefix: "osmo"})
+	rs.SetAssetList("osmosis", types.AssetList{ChainID: "osmosis-1", Assets: []types.AssetElement{
+		{Base: "uosmo", Display: "osmo", DenomUnits: []types.DenomUnitElement{{Denom: "uosmo"}}},
+	}})
+	rs.SetChain("cosmoshub", types.Chain{ChainName: "cosmoshub", ChainID: "cosmoshub-4", Bech32Prefix: "cosmos"})
+	return rs
+}
+
+func TestPull(t *testing.T) {
+	for _, archive := range []bool{false, true} {
+		rs := newTestRegistry()
+		h := newTestHandler(rs.URL)
+		h.SetArchive(archive)
+
+		if err := h.Pull(context.Background()); err != nil {
+			t.Fatalf("archive %v: pull: %v", archive, err)
+		}
+		if want := []string{"cosmoshub", "osmosis"}; !reflect.DeepEqual(h.chains, want) {
+			t.Errorf("archive %v: chains %v, want %v", archive, h.chains, want)
+		}
+		if _, ok := h.assetList["cosmoshub"]; ok {
+			t.Errorf("archive %v: cosmoshub has no asset list but one was pulled", archive)
+		}
+		if got := h.chainByAsset["osmo"]; got != "osmosis" {
+			t.Errorf("archive %v: osmo resolves to %q, want osmosis", archive, got)
+		}
+
+		rs.RemoveChain("cosmoshub")
+		rs.SetChain("osmosis", types.Chain{ChainName: "osmosis", ChainID: "osmosis-2", Bech32Prefix: "osmo"})
+		if err := h.Pull(context.Background()); err != nil {
+			t.Fatalf("archive %v: second pull: %v", archive, err)
+		}
+		if want := []string{"osmosis"}; !reflect.DeepEqual(h.chains, want) {
+			t.Errorf("archive %v: chains %v after removal, want %v", archive, h.chains, want)
+		}
+		if got := h.chainList["osmosis"].ChainID; got != "osmosis-2" {
+			t.Errorf("archive %v: chain id %q, want osmosis-2", archive, got)
+		}
+		if _, ok := h.tombstones["cosmoshub"]; !ok {
+			t.Errorf("archive %v: no tombstone for the removed chain", archive)
+		}
+		rs.Close()
+	}
+}
+
+// TestPullSingleFlight checks that callers of Pull while a pull is running
+// wait for it instead of starting their own
+func TestPullSingleFlight(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	upstream, _ := url.Parse(rs.URL)
+	proxy := httputil.NewSingleHostReverseProxy(upstream)
+
+	var mtx sync.Mutex
+	checks := 0
+	started, release := make(chan struct{}), make(chan struct{})
+	ps := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/commits") {
+			mtx.Lock()
+			checks++
+			first := checks == 1
+			mtx.Unlock()
+			if first {
+				close(started)
+				<-release
+			}
+		}
+		proxy.ServeHTTP(res, req)
+	}))
+	defer ps.Close()
+	h := newTestHandler(ps.URL)
+
+	errs := make(chan error, 3)
+	go func() { errs <- h.Pull(context.Background()) }()
+	<-started
+	for i := 0; i < 2; i++ {
+		go func() { errs <- h.Pull(context.Background()) }()
+	}
+	// give the other callers time to find the pull in progress
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("pull: %v", err)
+		}
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	if checks != 1 {
+		t.Errorf("registry was checked for changes %d times, want 1", checks)
+	}
+	if len(h.chains) != 2 {
+		t.Errorf("pulled %d chains, want 2", len(h.chains))
+	}
+}
+
+func TestPullSkipsMissingFiles(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	upstream, _ := url.Parse(rs.URL)
+	proxy := httputil.NewSingleHostReverseProxy(upstream)
+	var mtx sync.Mutex
+	requested := 0
+	ps := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/cosmoshub/assetlist.json") {
+			mtx.Lock()
+			requested++
+			mtx.Unlock()
+		}
+		proxy.ServeHTTP(res, req)
+	}))
+	defer ps.Close()
+	h := newTestHandler(ps.URL)
+
+	for i, chainID := range []string{"cosmoshub-4", "cosmoshub-5"} {
+		rs.SetChain("cosmoshub", types.Chain{ChainName: "cosmoshub", ChainID: chainID})
+		if err := h.Pull(context.Background()); err != nil {
+			t.Fatalf("pull %d: %v", i, err)
+		}
+	}
+	mtx.Lock()
+	if requested != 1 {
+		t.Errorf("missing asset list was requested %d times, want 1", requested)
+	}
+	mtx.Unlock()
+
+	rs.SetAssetList("cosmoshub", types.AssetList{ChainID: "cosmoshub-5"})
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := h.assetList["cosmoshub"]; !ok {
+		t.Error("asset list that appeared in the listing wasn't pulled")
+	}
+}
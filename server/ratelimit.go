@@ -0,0 +1,142 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// buckets that haven't been used for this long are dropped
+	bucketExpiry = 10 * time.Minute
+)
+
+// RateLimiter limits the rate of requests per client IP using a token bucket
+// for each client.
+type RateLimiter struct {
+	rate       float64 // tokens added per second
+	burst      float64 // maximum tokens in a bucket
+	trustProxy bool    // use X-Forwarded-For to identify clients
+	allowlist  map[string]struct{}
+
+	mtx       sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// NewRateLimiter creates a rate limiter allowing on average "rate" requests
+// per second from each client, with bursts of up to "burst" requests. When
+// trustProxy is set, the client IP is taken from the last address of the
+// X-Forwarded-For header, the one appended by the proxy in front of the server.
+// IPs in the allowlist are never limited.
+func NewRateLimiter(rate float64, burst int, trustProxy bool, allowlist []string) *RateLimiter {
+	allowed := make(map[string]struct{}, len(allowlist))
+	for _, ip := range allowlist {
+		allowed[ip] = struct{}{}
+	}
+	return &RateLimiter{
+		rate:       rate,
+		burst:      float64(burst),
+		trustProxy: trustProxy,
+		allowlist:  allowed,
+		buckets:    make(map[string]*bucket),
+		lastPrune:  time.Now(),
+	}
+}
+
+// Middleware rejects requests with 429 Too Many Requests once a client has
+// used up its bucket.
+func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		ip := rl.clientIP(req)
+		if rl.allowed(ip) {
+			next.ServeHTTP(res, req)
+			return
+		}
+		allowed, retryAfter := rl.take(ip, time.Now())
+		if !allowed {
+			tooManyRequests(res, retryAfter)
+			return
+		}
+		next.ServeHTTP(res, req)
+	})
+}
+
+// allowed reports whether the client is in the allowlist
+func (rl *RateLimiter) allowed(ip string) bool {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	_, ok := rl.allowlist[ip]
+	return ok
+}
+
+// update takes on the rate, burst and allowlist of another rate limiter,
+// keeping the buckets of the clients seen so far
+func (rl *RateLimiter) update(other *RateLimiter) {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	rl.rate, rl.burst, rl.allowlist = other.rate, other.burst, other.allowlist
+}
+
+// take removes a token from the client's bucket. If the bucket is empty it
+// returns false along with the time until the next token is available.
+func (rl *RateLimiter) take(ip string, now time.Time) (bool, time.Duration) {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	if now.Sub(rl.lastPrune) > bucketExpiry {
+		for key, b := range rl.buckets {
+			if now.Sub(b.lastSeen) > bucketExpiry {
+				delete(rl.buckets, key)
+			}
+		}
+		rl.lastPrune = now
+	}
+
+	b, ok := rl.buckets[ip]
+	if !ok {
+		b = &bucket{tokens: rl.burst, lastSeen: now}
+		rl.buckets[ip] = b
+	}
+	b.tokens = math.Min(rl.burst, b.tokens+now.Sub(b.lastSeen).Seconds()*rl.rate)
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rl.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+func (rl *RateLimiter) clientIP(req *http.Request) string {
+	if rl.trustProxy {
+		// only the last address was appended by the proxy, the ones before it
+		// are sent by the client and can be anything
+		if values := req.Header.Values("X-Forwarded-For"); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func tooManyRequests(w http.ResponseWriter, retryAfter time.Duration) {
+	setCORSHeaders(w)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	w.WriteHeader(http.StatusTooManyRequests)
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRateLimitSpoofedForwardedFor checks that clients behind a trusted proxy
+// can't escape the limit by prefixing X-Forwarded-For with made up addresses
+func TestRateLimitSpoofedForwardedFor(t *testing.T) {
+	rl := NewRateLimiter(0.001, 2, true, nil)
+	handler := rl.Middleware(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {}))
+	codes := make([]int, 4)
+	for i := range codes {
+		req := httptest.NewRequest(http.MethodGet, "/v1/chains", nil)
+		req.RemoteAddr = "10.0.0.1:4321"
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d, 203.0.113.7", i))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		codes[i] = rec.Code
+	}
+	want := []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Fatalf("statuses %v, want %v", codes, want)
+		}
+	}
+
+	// another client of the proxy has a bucket of its own
+	req := httptest.NewRequest(http.MethodGet, "/v1/chains", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.8")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("other client got %d", rec.Code)
+	}
+}
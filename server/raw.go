@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// RawFile serves the chain.json or assetlist.json of a chain exactly as it
+// was pulled from the registry, for tools that expect the upstream files.
+// Chains restored from a snapshot have no files to serve until the next pull.
+func (h *Handler) RawFile(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	name, ok := h.resolveChain(vars["chain"])
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	file, ok := h.files[name+"/"+vars["file"]]
+	if !ok {
+		resourceNotFound(res)
+		return
+	}
+	respondWithBytes(res, "application/json", file)
+}
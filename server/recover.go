@@ -0,0 +1,27 @@
+package server
+
+import (
+	"net/http"
+	"runtime/debug"
+)
+
+// Recover is middleware that answers requests whose handler panics with 500
+// Internal Server Error and logs the stack, rather than dropping the
+// connection without a response
+func (h *Handler) Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		defer func() {
+			err := recover()
+			if err == nil {
+				return
+			}
+			// the handler deliberately aborted the response
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
+			h.log.Printf("panic serving %s: %v\n%s", req.URL.Path, err, debug.Stack())
+			internalError(res)
+		}()
+		next.ServeHTTP(res, req)
+	})
+}
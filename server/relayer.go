@@ -0,0 +1,127 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// hermesConfig renders the chains as `[[chains]]` entries of a Hermes
+// config.toml. Only fields that can be derived from the registry are set,
+// the rest are given Hermes' recommended defaults.
+// TODO: Add packet filters once relayer paths are supported
+func hermesConfig(chains []types.Chain) string {
+	var sb strings.Builder
+	for i, chain := range chains {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		rpc, grpc := "", ""
+		if chain.Apis != nil {
+			if len(chain.Apis.RPC) > 0 {
+				rpc = chain.Apis.RPC[0].Address
+			}
+			if len(chain.Apis.Grpc) > 0 {
+				grpc = withScheme(chain.Apis.Grpc[0].Address, "http")
+			}
+		}
+		denom, price := gasPrice(chain)
+
+		sb.WriteString("[[chains]]\n")
+		fmt.Fprintf(&sb, "id = '%s'\n", chain.ChainID)
+		fmt.Fprintf(&sb, "rpc_addr = '%s'\n", rpc)
+		fmt.Fprintf(&sb, "grpc_addr = '%s'\n", grpc)
+		fmt.Fprintf(&sb, "websocket_addr = '%s'\n", websocketAddr(rpc))
+		sb.WriteString("rpc_timeout = '10s'\n")
+		fmt.Fprintf(&sb, "account_prefix = '%s'\n", chain.Bech32Prefix)
+		fmt.Fprintf(&sb, "key_name = '%s'\n", chain.ChainName)
+		sb.WriteString("store_prefix = 'ibc'\n")
+		sb.WriteString("max_gas = 3000000\n")
+		fmt.Fprintf(&sb, "gas_price = { price = %g, denom = '%s' }\n", price, denom)
+		sb.WriteString("clock_drift = '5s'\n")
+		sb.WriteString("trusting_period = '14days'\n")
+		sb.WriteString("trust_threshold = { numerator = '1', denominator = '3' }\n")
+		if isEthermint(chain) {
+			sb.WriteString("address_type = { derivation = 'ethermint', proto_type = { pk_type = '/ethermint.crypto.v1.ethsecp256k1.PubKey' } }\n")
+		} else {
+			sb.WriteString("address_type = { derivation = 'cosmos' }\n")
+		}
+	}
+	return sb.String()
+}
+
+// rlyChain converts a chain into the chain file used by the go relayer. Like
+// hermesConfig, fields that can't be derived from the registry use rly's defaults.
+func rlyChain(chain types.Chain) types.RlyChain {
+	rpc := ""
+	if chain.Apis != nil && len(chain.Apis.RPC) > 0 {
+		rpc = chain.Apis.RPC[0].Address
+	}
+	gasPrices := ""
+	if denom, price := gasPrice(chain); denom != "" {
+		gasPrices = fmt.Sprintf("%g%s", price, denom)
+	}
+	return types.RlyChain{
+		Type: "cosmos",
+		Value: types.RlyChainValue{
+			Key:            "default",
+			ChainID:        chain.ChainID,
+			RPCAddr:        rpc,
+			AccountPrefix:  chain.Bech32Prefix,
+			KeyringBackend: "test",
+			GasAdjustment:  1.2,
+			GasPrices:      gasPrices,
+			Timeout:        "20s",
+			OutputFormat:   "json",
+			SignMode:       "direct",
+		},
+	}
+}
+
+// gasPrice returns the denom and minimum gas price of the chain's first fee token
+func gasPrice(chain types.Chain) (string, float64) {
+	if chain.Fees == nil || len(chain.Fees.FeeTokens) == 0 {
+		return "", 0
+	}
+	token := chain.Fees.FeeTokens[0]
+	if token.FixedMinGasPrice == nil {
+		return token.Denom, 0
+	}
+	return token.Denom, *token.FixedMinGasPrice
+}
+
+func isEthermint(chain types.Chain) bool {
+	for _, algo := range chain.KeyAlgos {
+		if algo == types.Ethsecp256K1 {
+			return true
+		}
+	}
+	return false
+}
+
+// websocketAddr derives the tendermint websocket address from an RPC address
+func websocketAddr(rpc string) string {
+	if rpc == "" {
+		return ""
+	}
+	rpc = strings.TrimSuffix(rpc, "/")
+	switch {
+	case strings.HasPrefix(rpc, "https://"):
+		rpc = "wss://" + strings.TrimPrefix(rpc, "https://")
+	case strings.HasPrefix(rpc, "http://"):
+		rpc = "ws://" + strings.TrimPrefix(rpc, "http://")
+	default:
+		rpc = "ws://" + rpc
+	}
+	return rpc + "/websocket"
+}
+
+// withScheme prefixes an address with the scheme if it doesn't already have one.
+// The registry often lists gRPC endpoints as plain host:port pairs.
+func withScheme(addr, scheme string) string {
+	if strings.Contains(addr, "://") {
+		return addr
+	}
+	return scheme + "://" + addr
+}
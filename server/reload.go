@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+
+	cron "github.com/robfig/cron/v3"
+)
+
+// Reload replaces the pull interval, chain filter, aliases, previous chain
+// ids, notifiers and rate limits of the server with those given by the
+// options, keeping the registry it serves. Other options are ignored. A change
+// to the chain filter pulls the registry again.
+func (s *Server) Reload(opts ...Option) error {
+	o := options{pullInterval: defaultPullInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if _, err := cron.ParseStandard(o.pullInterval); err != nil {
+		return fmt.Errorf("invalid pull interval %q: %w", o.pullInterval, err)
+	}
+	if o.client == nil {
+		o.client = s.opts.client
+	}
+	if o.client != nil {
+		o.useHTTPClient(o.client)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if o.pullInterval != s.opts.pullInterval && s.updater != nil && s.opts.pin == "" {
+		if err := s.updater.reschedule(o.pullInterval); err != nil {
+			return err
+		}
+		s.log.Printf("cron scheduler running with update frequency: %s", o.pullInterval)
+	}
+	s.opts.pullInterval = o.pullInterval
+
+	switch {
+	case s.opts.rateLimiter != nil && o.rateLimiter != nil:
+		s.opts.rateLimiter.update(o.rateLimiter)
+	case s.opts.rateLimiter != nil || o.rateLimiter != nil:
+		s.log.Print("enabling or disabling the rate limit requires a restart")
+	}
+
+	h := s.handler
+	h.mtx.Lock()
+	filter := chainFilter{allow: set(o.allowChains), deny: set(o.denyChains)}
+	refilter := !reflect.DeepEqual(filter, h.filter)
+	if refilter {
+		h.filter = filter
+		h.refilter = true
+	}
+	h.aliases = o.aliases
+	h.prevIDs = o.prevChainIDs
+	h.index()
+	h.cache.reset()
+	// notifiers added with AddNotifier are kept
+	h.optNotifiers = o.notifiers
+	h.mtx.Unlock()
+
+	// a follower never pulls, it applies the filter to the next snapshot of
+	// its primary instead
+	if _, follower := s.opts.sharedStore.(*Primary); refilter && s.updater != nil && !follower {
+		s.updater.trigger()
+	}
+	s.log.Print("reloaded settings")
+	return nil
+}
+
+// reload reloads the settings from the options given by WithReload
+func (s *Server) reload() error {
+	if s.opts.reload == nil {
+		return errors.New("reloading isn't enabled")
+	}
+	opts, err := s.opts.reload()
+	if err != nil {
+		return err
+	}
+	return s.Reload(opts...)
+}
+
+// reloadOnHangup reloads the settings whenever the process receives SIGHUP,
+// until the context is cancelled
+func (s *Server) reloadOnHangup(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+	for {
+		select {
+		case <-hangup:
+			if err := s.reload(); err != nil {
+				s.log.Printf("reloading settings: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Reload reloads the settings of the server, see WithReload
+func (h *Handler) Reload(res http.ResponseWriter, req *http.Request) {
+	if err := h.reload(); err != nil {
+		h.log.Printf("reloading settings: %v", err)
+		internalError(res)
+		return
+	}
+	res.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+type testNotifier struct{ name string }
+
+func (testNotifier) Notify(context.Context, string, types.Changes) error { return nil }
+
+func TestReloadKeepsAddedNotifiers(t *testing.T) {
+	s, err := New(WithRegistry("cosmos/chain-registry"), WithLogger(log.New(io.Discard, "", 0)), WithNotifiers(testNotifier{"configured"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	added := testNotifier{"added"}
+	s.handler.AddNotifier(added)
+
+	reloaded := testNotifier{"reloaded"}
+	if err := s.Reload(WithNotifiers(reloaded)); err != nil {
+		t.Fatal(err)
+	}
+	h := s.handler
+	if len(h.optNotifiers) != 1 || h.optNotifiers[0] != reloaded {
+		t.Errorf("configured notifiers %v after reload, want %v", h.optNotifiers, reloaded)
+	}
+	if len(h.notifiers) != 1 || h.notifiers[0] != added {
+		t.Errorf("added notifiers %v after reload, want %v", h.notifiers, added)
+	}
+}
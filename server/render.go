@@ -0,0 +1,88 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// renderedChainQueries are the queries of each chain that are rendered. Those
+// that download genesis files, query the chain or aren't JSON are left out.
+var renderedChainQueries = []string{
+	"",
+	"/assets",
+	"/endpoints/rpc",
+	"/endpoints/rest",
+	"/endpoints/grpc",
+	"/endpoints/peers",
+	"/endpoints/seeds",
+	"/bank-metadata",
+	"/upgrades",
+	"/gas-price",
+	"/staking",
+	"/history",
+	"/chain-ids",
+	"/keplr",
+}
+
+// Render writes the response to every JSON query of the served registry to a
+// file in dir, named after the query's path with a .json extension, e.g.
+// v1/chain/osmosis/assets.json, so that the directory can be hosted on a CDN
+// or IPFS. Queries the registry has no answer for are skipped. The requests
+// go through the server's middleware, so it shouldn't be rate limited.
+func (s *Server) Render(dir string) (int, error) {
+	written := 0
+	for _, path := range s.renderPaths() {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			continue
+		}
+		name, err := url.PathUnescape(strings.TrimPrefix(path, "/"))
+		if err != nil {
+			return written, err
+		}
+		file := filepath.Join(dir, filepath.FromSlash(name)+".json")
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(file, rec.Body.Bytes(), 0o644); err != nil {
+			return written, fmt.Errorf("writing %s: %w", file, err)
+		}
+		written++
+	}
+	return written, nil
+}
+
+// renderPaths returns the paths of the queries that Render renders
+func (s *Server) renderPaths() []string {
+	h := s.handler
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	paths := []string{"/versions", "/integrity"}
+	for _, version := range apiVersions {
+		prefix := "/" + version.prefix
+		for _, path := range []string{"/chains", "/tombstones", "/assets", "/assets/all", "/stats", "/stats/registry", "/stats/providers", "/aliases", "/providers", "/gas-prices", "/images"} {
+			paths = append(paths, prefix+path)
+		}
+		for _, name := range h.chains {
+			for _, query := range renderedChainQueries {
+				paths = append(paths, prefix+"/chain/"+url.PathEscape(name)+query)
+			}
+		}
+		for _, asset := range h.assets {
+			// display names with a slash can't be told apart from a path
+			if strings.Contains(asset, "/") {
+				continue
+			}
+			paths = append(paths, prefix+"/asset/"+url.PathEscape(asset), prefix+"/asset/"+url.PathEscape(asset)+"/lineage")
+		}
+	}
+	return paths
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// apiVersions lists every version of the API that is served. Each version is
+// mounted under its own path prefix so that response shapes can change in a
+// new version without breaking the clients of an older one.
+var apiVersions = []apiVersion{
+	{prefix: "v1", register: v1Routes},
+	{prefix: "v2", register: v2Routes},
+}
+
+type apiVersion struct {
+	prefix   string
+	register func(router *mux.Router, handler *Handler, o options)
+}
+
+// streamingRoute prefixes the names of the routes whose responses are streamed
+// rather than buffered, such as genesis files and the mirror, which can be too
+// large to buffer or to send in time. The Timeout and signing middleware leave
+// them alone, as both buffer the response.
+const streamingRoute = "streaming "
+
+// streaming returns true if the request is served by a streaming route
+func streaming(req *http.Request) bool {
+	route := mux.CurrentRoute(req)
+	return route != nil && strings.HasPrefix(route.GetName(), streamingRoute)
+}
+
+// registerRoutes mounts every version of the API on the router
+func registerRoutes(router *mux.Router, handler *Handler, o options) {
+	versions := make([]string, 0, len(apiVersions))
+	for _, version := range apiVersions {
+		version.register(router.PathPrefix("/"+version.prefix).Subrouter(), handler, o)
+		versions = append(versions, version.prefix)
+	}
+	router.HandleFunc("/versions", func(res http.ResponseWriter, req *http.Request) {
+		respondWithJSON(res, versions)
+	}).Methods("GET")
+	// the registry files as pulled, in the layout of the registry
+	router.HandleFunc("/raw/{chain}/{file:chain\\.json|assetlist\\.json}", handler.RawFile).Methods("GET")
+	router.HandleFunc("/integrity", handler.Integrity).Methods("GET")
+	router.HandleFunc("/schemas", handler.Schemas).Methods("GET")
+	router.HandleFunc("/schemas/{schema:chain\\.schema\\.json|assetlist\\.schema\\.json|ibc_data\\.schema\\.json}", handler.Schema).Methods("GET")
+	router.HandleFunc("/batch", Batch(router)).Methods("POST")
+	// servers following this one load the registry from its snapshots
+	replicationRouter := router.PathPrefix("/replication").Subrouter()
+	replicationRouter.Use(o.apiKeys.RequireScope(ScopeReplication))
+	replicationRouter.HandleFunc("/snapshot", handler.ReplicationSnapshot).Methods("GET").Name(streamingRoute + "snapshot")
+	if o.mirrorDir != "" {
+		router.PathPrefix("/mirror/").HandlerFunc(handler.Mirror).Methods("GET").Name(streamingRoute + "mirror")
+	}
+	// previews of other refs of the registry are served with the same routes,
+	// and their genesis files are streamed like those of the registry
+	handler.previews.opts = o
+	router.HandleFunc("/preview/{preview}/{genesis:v[0-9]+/chain/[^/]+/genesis}", handler.Preview).Methods("GET").Name(streamingRoute + "preview genesis")
+	router.PathPrefix("/preview/{preview}/").HandlerFunc(handler.Preview).Methods("GET")
+
+	// admin routes can modify the server so they are only served when
+	// authentication is enabled
+	if len(o.apiKeys) > 0 || o.adminCAs != nil || o.adminBasic != nil {
+		adminRoutes(router.PathPrefix("/admin").Subrouter(), handler, o)
+	}
+}
+
+// useRegistryMiddleware mounts the middleware that depends on the registry
+// the handler serves, on the main router as on the router of each preview
+func useRegistryMiddleware(router *mux.Router, handler *Handler) {
+	router.Use(handler.RegistryAge)
+	router.Use(handler.Maintenance)
+	router.Use(handler.DeprecatedChainID)
+	router.Use(handler.Gone)
+}
+
+func adminRoutes(router *mux.Router, handler *Handler, o options) {
+	router.Use(handler.Audit)
+	if o.adminCAs != nil {
+		router.Use(RequireClientCert)
+	}
+	router.Use(o.requireAdmin)
+	router.HandleFunc("/export", handler.Export).Methods("GET").Name(streamingRoute + "export")
+	router.HandleFunc("/import", handler.Import).Methods("POST")
+	router.HandleFunc("/maintenance", handler.GetMaintenance).Methods("GET")
+	router.HandleFunc("/maintenance", handler.SetMaintenance).Methods("POST")
+	router.HandleFunc("/maintenance", handler.EndMaintenance).Methods("DELETE")
+	router.HandleFunc("/audit", handler.AuditLog).Methods("GET")
+	router.HandleFunc("/usage", handler.Usage).Methods("GET")
+	router.HandleFunc("/compare", handler.Compare).Methods("GET")
+	router.HandleFunc("/previews", handler.ListPreviews).Methods("GET")
+	router.HandleFunc("/previews/{name}", handler.CreatePreview).Methods("POST")
+	router.HandleFunc("/previews/{name}", handler.DeletePreview).Methods("DELETE")
+	if o.reload != nil {
+		router.HandleFunc("/reload", handler.Reload).Methods("POST")
+	}
+}
+
+func v1Routes(router *mux.Router, handler *Handler, o options) {
+	router.HandleFunc("/chains", handler.Chains).Methods("GET")
+	router.HandleFunc("/tombstones", handler.Tombstones).Methods("GET")
+	router.HandleFunc("/chains/batch", handler.ChainBatch).Methods("GET", "POST")
+	router.HandleFunc("/chain/{chain}", handler.Chain).Methods("GET")
+	router.HandleFunc("/chain/{chain}/endpoints/{type}", handler.Endpoints).Methods("GET")
+	router.HandleFunc("/chain/{chain}/assets", handler.ChainAsset).Methods("GET")
+	router.HandleFunc("/chain/{chain}/asset/{asset}", handler.ChainAssetByName).Methods("GET")
+	router.HandleFunc("/chain/{chain}/bank-metadata", handler.BankMetadata).Methods("GET")
+	router.HandleFunc("/chain/{chain}/explorer/tx/{hash}", handler.ExplorerTx).Methods("GET")
+	router.HandleFunc("/chain/{chain}/explorer/account/{address}", handler.ExplorerAccount).Methods("GET")
+	router.HandleFunc("/chain/{chain}/addrbook", handler.AddrBook).Methods("GET")
+	router.HandleFunc("/chain/{chain}/config/p2p", handler.P2PConfig).Methods("GET")
+	router.HandleFunc("/chain/{chain}/config/node", handler.P2PConfig).Methods("GET")
+	router.HandleFunc("/chain/{chain}/config/app", handler.AppConfig).Methods("GET")
+	router.HandleFunc("/chain/{chain}/genesis", handler.Genesis).Methods("GET").Name(streamingRoute + "genesis")
+	router.HandleFunc("/chain/{chain}/genesis-info", handler.GenesisInfo).Methods("GET")
+	router.HandleFunc("/chain/{chain}/upgrades", handler.Upgrades).Methods("GET")
+	router.HandleFunc("/chain/{chain}/image", handler.ChainImage).Methods("GET")
+	router.HandleFunc("/chain/{chain}/gas-price", handler.ChainGasPrice).Methods("GET")
+	router.HandleFunc("/chain/{chain}/fees", handler.ChainGasPrice).Methods("GET")
+	router.HandleFunc("/chain/{chain}/staking", handler.ChainStaking).Methods("GET")
+	router.HandleFunc("/chain/{chain}/history", handler.History).Methods("GET")
+	router.HandleFunc("/chain/{chain}/chain-ids", handler.ChainIDs).Methods("GET")
+	router.HandleFunc("/chain/{chain}/keplr", handler.Keplr).Methods("GET")
+	router.HandleFunc("/chain/{chain}/wallet/{provider}", handler.Wallet).Methods("GET")
+	// relayer configs can span many chains and are restricted to known clients
+	relayerRouter := router.PathPrefix("/relayer").Subrouter()
+	relayerRouter.Use(o.apiKeys.RequireScope(ScopeBulk))
+	relayerRouter.HandleFunc("/hermes/config", handler.HermesConfig).Methods("GET")
+	// TODO: Add /rly/path/{chain1}-{chain2} once relayer paths are pulled
+	relayerRouter.HandleFunc("/rly/chains/{chain}", handler.RlyChain).Methods("GET")
+	router.HandleFunc("/convert/address", handler.ConvertAddress).Methods("GET")
+	router.HandleFunc("/aliases", handler.Aliases).Methods("GET")
+	router.HandleFunc("/providers", handler.Providers).Methods("GET")
+	router.HandleFunc("/indexes", handler.Indexes).Methods("GET")
+	router.HandleFunc("/indexes/{name}", handler.CustomIndex).Methods("GET")
+	router.HandleFunc("/gas-prices", handler.GasPrices).Methods("GET")
+	router.HandleFunc("/changes", handler.Changes).Methods("GET").Queries("since", "{since}")
+	router.HandleFunc("/stats", handler.Stats).Methods("GET")
+	router.HandleFunc("/stats/registry", handler.RegistryStats).Methods("GET")
+	router.HandleFunc("/stats/providers", handler.ProviderStats).Methods("GET")
+	router.HandleFunc("/assets", handler.Assets).Methods("GET")
+	router.HandleFunc("/assets/all", handler.AllAssets).Methods("GET")
+	router.HandleFunc("/asset/{asset}", handler.Asset).Methods("GET")
+	router.HandleFunc("/asset/{asset}/image", handler.AssetImage).Methods("GET")
+	router.HandleFunc("/images", handler.Images).Methods("GET")
+	router.HandleFunc("/asset/{asset}/supply", handler.AssetSupply).Methods("GET")
+	router.HandleFunc("/asset/{asset}/lineage", handler.AssetLineage).Methods("GET")
+}
+
+// v2Routes serves the routes of v1, apart from /asset/{asset} which returns
+// every asset with the display name, since they aren't unique across chains
+func v2Routes(router *mux.Router, handler *Handler, o options) {
+	// routes are matched in the order they are added
+	router.HandleFunc("/asset/{asset}", handler.AssetMatches).Methods("GET")
+	v1Routes(router, handler, o)
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the sha256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Source pulls the registry from a bucket of an S3 compatible object store,
+// such as AWS S3, Google Cloud Storage or MinIO. The bucket mirrors the layout
+// of the registry repo under Prefix.
+type S3Source struct {
+	// Endpoint of the object store, e.g. https://s3.us-east-1.amazonaws.com
+	// or https://storage.googleapis.com. Buckets are addressed by path.
+	Endpoint string
+	Bucket   string
+	// Prefix of the registry within the bucket, e.g. "chain-registry/"
+	Prefix string
+	// Region the requests are signed for. GCS uses "auto".
+	Region string
+	// AccessKeyID and SecretAccessKey sign requests with AWS signature
+	// version 4. Requests are unsigned when they are empty, which only works
+	// for public buckets.
+	AccessKeyID     string
+	SecretAccessKey string
+	// Client makes the requests. Defaults to a client shared by skychart.
+	Client *http.Client
+}
+
+var _ Source = S3Source{}
+
+// listBucketResult is the response of ListObjectsV2
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+}
+
+// Changed returns true if any object of the registry was modified since the
+// given time. Deleted objects aren't detected until another object changes.
+func (s S3Source) Changed(ctx context.Context, since time.Time) (bool, error) {
+	changed := false
+	err := s.list(ctx, "", func(result listBucketResult) {
+		for _, object := range result.Contents {
+			if object.LastModified.After(since) {
+				changed = true
+			}
+		}
+	})
+	return changed, err
+}
+
+func (s S3Source) Chains(ctx context.Context) ([]string, error) {
+	chains := make([]string, 0)
+	err := s.list(ctx, "/", func(result listBucketResult) {
+		for _, prefix := range result.CommonPrefixes {
+			name := strings.TrimSuffix(strings.TrimPrefix(prefix.Prefix, s.Prefix), "/")
+			if isChainDir(name) {
+				chains = append(chains, name)
+			}
+		}
+	})
+	return chains, err
+}
+
+func (s S3Source) File(ctx context.Context, chain, file string) ([]byte, bool, error) {
+	return s.get(ctx, s.Prefix+chain+"/"+file, nil)
+}
+
+// RootFile returns the object of a file at the root of the registry
+func (s S3Source) RootFile(ctx context.Context, _, file string) ([]byte, bool, error) {
+	return s.get(ctx, s.Prefix+file, nil)
+}
+
+// list pages through the objects of the registry with ListObjectsV2
+func (s S3Source) list(ctx context.Context, delimiter string, page func(listBucketResult)) error {
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.Prefix}}
+		if delimiter != "" {
+			query.Set("delimiter", delimiter)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		bz, found, err := s.get(ctx, "", query)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("bucket %s not found", s.Bucket)
+		}
+		var result listBucketResult
+		if err := xml.Unmarshal(bz, &result); err != nil {
+			return fmt.Errorf("unmarshalling bucket listing: %w", err)
+		}
+		page(result)
+		if !result.IsTruncated {
+			return nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// get returns the object at key, or lists the bucket if key is empty. It
+// returns false if the object doesn't exist.
+func (s S3Source) get(ctx context.Context, key string, query url.Values) ([]byte, bool, error) {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return nil, false, err
+	}
+	u := *endpoint
+	u.Path = strings.TrimSuffix(endpoint.Path, "/") + "/" + s.Bucket + "/" + key
+	// encode spaces as %20 rather than + as required by the signature
+	u.RawQuery = strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if s.AccessKeyID != "" {
+		s.sign(req, time.Now().UTC())
+	}
+	resp, err := httpClient(s.Client).Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("unexpected status code for query %s: %d", u.String(), resp.StatusCode)
+	}
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	return bz, true, nil
+}
+
+// sign adds an AWS signature version 4 to a GET request
+func (s S3Source) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + emptyPayloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(hash[:])}, "\n")
+
+	key := []byte("AWS4" + s.SecretAccessKey)
+	for _, part := range []string{date, s.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
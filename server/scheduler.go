@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+)
+
+const (
+	minBackoff = time.Minute
+	maxBackoff = time.Hour
+)
+
+// scheduler runs the handler's pulls. Each pull is delayed by a random jitter
+// so that a fleet of servers don't all hit github at once. When github rate
+// limits a pull, further pulls are skipped until the quota resets, at which
+// point a pull is retried and the normal cadence resumes.
+type scheduler struct {
+	handler *Handler
+	jitter  time.Duration
+	log     *log.Logger
+	replica *replica // nil unless the registry is shared with other replicas
+	store   Store    // nil unless the registry is persisted
+
+	mtx      sync.Mutex
+	ctx      context.Context
+	cron     *cron.Cron
+	entry    cron.EntryID
+	rand     *rand.Rand
+	backoff  time.Duration
+	resumeAt time.Time
+	retry    *time.Timer
+}
+
+func newScheduler(handler *Handler, jitter time.Duration, replica *replica, store Store, log *log.Logger) *scheduler {
+	return &scheduler{
+		handler: handler,
+		jitter:  jitter,
+		replica: replica,
+		store:   store,
+		log:     log,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		backoff: minBackoff,
+	}
+}
+
+// run is called on every scheduled update
+func (s *scheduler) run(ctx context.Context) {
+	// only the leading replica pulls, the others load its snapshot
+	if s.replica != nil && !s.replica.isLeader() {
+		return
+	}
+
+	// the registry is frozen during maintenance
+	if s.handler.inMaintenance() {
+		s.log.Print("skipping pull, server is in maintenance mode")
+		return
+	}
+
+	s.mtx.Lock()
+	if time.Now().Before(s.resumeAt) {
+		s.mtx.Unlock()
+		s.log.Printf("skipping pull, backing off until %s", s.resumeAt.Format(time.RFC3339))
+		return
+	}
+	var delay time.Duration
+	if s.jitter > 0 {
+		delay = time.Duration(s.rand.Int63n(int64(s.jitter)))
+	}
+	s.mtx.Unlock()
+
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		return
+	}
+
+	err := s.handler.Pull(ctx)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		if err != nil {
+			s.log.Print(err)
+		} else {
+			s.saved(ctx)
+		}
+		s.backoff = minBackoff
+		return
+	}
+
+	// back off until the quota resets or, if github didn't say when that
+	// is, exponentially
+	s.resumeAt = rateLimitErr.Reset
+	if s.resumeAt.IsZero() {
+		s.resumeAt = time.Now().Add(s.backoff)
+		s.backoff *= 2
+		if s.backoff > maxBackoff {
+			s.backoff = maxBackoff
+		}
+	}
+	s.log.Printf("%v: backing off until %s", err, s.resumeAt.Format(time.RFC3339))
+
+	// retry once the quota resets instead of waiting for the next update
+	if s.retry != nil {
+		s.retry.Stop()
+	}
+	s.retry = time.AfterFunc(time.Until(s.resumeAt), func() {
+		s.run(ctx)
+	})
+}
+
+// saved shares and persists the registry after a successful pull
+func (s *scheduler) saved(ctx context.Context) {
+	if s.replica != nil && s.replica.isLeader() {
+		s.replica.publish(ctx)
+	}
+	if s.store != nil {
+		if err := s.store.Save(ctx, s.handler.Snapshot()); err != nil {
+			s.log.Printf("saving registry: %v", err)
+		}
+	}
+}
+
+// schedule runs the scheduler on the cron spec
+func (s *scheduler) schedule(ctx context.Context, spec string) error {
+	c := cron.New(cron.WithLogger(cron.PrintfLogger(s.log)))
+	entry, err := c.AddFunc(spec, func() {
+		// update the servers local records
+		s.run(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	s.ctx, s.cron, s.entry = ctx, c, entry
+	s.mtx.Unlock()
+	c.Start()
+	return nil
+}
+
+// reschedule replaces the cron spec of the schedule. A pull that is running
+// is left to finish.
+func (s *scheduler) reschedule(spec string) error {
+	s.mtx.Lock()
+	ctx, previous := s.ctx, s.cron
+	s.mtx.Unlock()
+	if previous == nil {
+		return errors.New("no pull is scheduled")
+	}
+	if err := s.schedule(ctx, spec); err != nil {
+		return err
+	}
+	previous.Stop()
+	return nil
+}
+
+// trigger pulls now rather than at the next scheduled time
+func (s *scheduler) trigger() {
+	s.mtx.Lock()
+	ctx := s.ctx
+	s.mtx.Unlock()
+	if ctx != nil {
+		go s.run(ctx)
+	}
+}
+
+// next returns when the scheduler will next pull, or the zero time if no
+// pull is scheduled
+func (s *scheduler) next() time.Time {
+	if s.replica != nil && !s.replica.isLeader() {
+		return time.Time{}
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.cron == nil {
+		return time.Time{}
+	}
+	if time.Now().Before(s.resumeAt) {
+		return s.resumeAt
+	}
+	return s.cron.Entry(s.entry).Next
+}
+
+// stop stops the schedule and cancels any pending retry. It waits for a
+// scheduled pull that is running to return.
+func (s *scheduler) stop() {
+	s.mtx.Lock()
+	var running context.Context
+	if s.cron != nil {
+		running = s.cron.Stop()
+	}
+	if s.retry != nil {
+		s.retry.Stop()
+	}
+	s.mtx.Unlock()
+	if running != nil {
+		<-running.Done()
+	}
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// Versions of the registry schema. The registry doesn't version its files, so
+// the version is detected from the shape of each file.
+const (
+	// schemaV1 is the original schema that the internal types follow
+	schemaV1 = "v1"
+	// schemaV2 moved the genesis into the codebase, keyed binaries by their
+	// full architecture, replaced the ibc section of assets with traces and
+	// renamed their kind to type_asset
+	schemaV2 = "v2"
+)
+
+// schemaReport records the detected schema version of every file of a pull
+// and the fields that the internal types don't know about, which are
+// otherwise dropped silently. Files are keyed as chain/file.
+type schemaReport struct {
+	versions map[string]string
+	unknown  map[string][]string
+}
+
+func newSchemaReport() *schemaReport {
+	return &schemaReport{versions: make(map[string]string), unknown: make(map[string][]string)}
+}
+
+func (r *schemaReport) record(file, version string, unknown []string) {
+	r.versions[file] = version
+	if len(unknown) > 0 {
+		r.unknown[file] = unknown
+	}
+}
+
+// chainFile is a chain.json of either schema version. Its fields shadow the
+// fields of the internal type that the newer schema changed.
+type chainFile struct {
+	types.Chain
+	Codebase *codebaseFile `json:"codebase,omitempty"`
+	Slip44   *coinType     `json:"slip44,omitempty"`
+}
+
+type codebaseFile struct {
+	types.Codebase
+	Binaries *binariesFile `json:"binaries,omitempty"`
+	// Genesis moved into the codebase in the newer schema
+	Genesis *types.Genesis `json:"genesis,omitempty"`
+}
+
+type binariesFile struct {
+	types.Binaries
+	// LinuxAMD64 replaced linux/amd in the newer schema
+	LinuxAMD64 *string `json:"linux/amd64,omitempty"`
+}
+
+// coinType is a slip44 coin type, which a few chains have published as a
+// string
+type coinType float64
+
+func (c *coinType) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err == nil {
+		bz = []byte(s)
+	}
+	return json.Unmarshal(bz, (*float64)(c))
+}
+
+// decodeChain decodes a chain.json of any schema version straight into the
+// internal type. It returns the detected version.
+func decodeChain(r io.Reader) (types.Chain, string, error) {
+	var file chainFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return types.Chain{}, "", err
+	}
+
+	chain, version := file.Chain, schemaV1
+	if file.Slip44 != nil {
+		slip44 := float64(*file.Slip44)
+		chain.Slip44 = &slip44
+	}
+	if codebase := file.Codebase; codebase != nil {
+		chain.Codebase = &codebase.Codebase
+		if codebase.Genesis != nil {
+			version = schemaV2
+			if chain.Genesis == nil {
+				chain.Genesis = codebase.Genesis
+			}
+		}
+		if binaries := codebase.Binaries; binaries != nil {
+			chain.Codebase.Binaries = &binaries.Binaries
+			if binaries.LinuxAMD64 != nil {
+				version = schemaV2
+				if binaries.LinuxAMD == nil {
+					binaries.LinuxAMD = binaries.LinuxAMD64
+				}
+			}
+		}
+	}
+	return chain, version, nil
+}
+
+// assetListFile is an assetlist.json of either schema version
+type assetListFile struct {
+	types.AssetList
+	Assets []assetFile `json:"assets"`
+}
+
+type assetFile struct {
+	types.AssetElement
+	// TypeAsset replaced kind in the newer schema
+	TypeAsset *types.Kind `json:"type_asset,omitempty"`
+}
+
+// decodeAssetList decodes an assetlist.json of any schema version straight
+// into the internal type. It returns the detected version.
+func decodeAssetList(r io.Reader) (types.AssetList, string, error) {
+	var file assetListFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return types.AssetList{}, "", err
+	}
+
+	assetList, version := file.AssetList, schemaV1
+	if file.Assets != nil {
+		assetList.Assets = make([]types.AssetElement, len(file.Assets))
+	}
+	for i, asset := range file.Assets {
+		if asset.TypeAsset != nil {
+			version = schemaV2
+			if asset.Kind == nil {
+				asset.Kind = asset.TypeAsset
+			}
+		}
+		if asset.Traces != nil {
+			version = schemaV2
+			if asset.Ibc == nil {
+				asset.Ibc = ibcFromTraces(asset.Traces)
+			}
+		}
+		assetList.Assets[i] = asset.AssetElement
+	}
+	return assetList, version, nil
+}
+
+// ibcFromTraces converts the last ibc trace of an asset into the ibc section
+// of the original schema
+func ibcFromTraces(traces []types.Trace) *types.Ibc {
+	for i := len(traces) - 1; i >= 0; i-- {
+		trace := traces[i]
+		if trace.Type != "ibc" {
+			continue
+		}
+		ibc := &types.Ibc{
+			SourceChannel: trace.Counterparty.ChannelID,
+			SourceDenom:   trace.Counterparty.BaseDenom,
+			SourceChain:   trace.Counterparty.ChainName,
+		}
+		if trace.Chain != nil {
+			ibc.DstChannel = trace.Chain.ChannelID
+		}
+		return ibc
+	}
+	return nil
+}
+
+// skipped is a JSON value that is checked but not decoded, so that the fields
+// of a file can be listed without copying their values
+type skipped struct{}
+
+func (*skipped) UnmarshalJSON([]byte) error {
+	return nil
+}
+
+// unknownChainFields returns the sorted top level fields of a chain.json that
+// the internal type doesn't know about
+func unknownChainFields(file []byte) []string {
+	var doc map[string]skipped
+	if err := json.Unmarshal(file, &doc); err != nil {
+		return nil
+	}
+	return unknownFields(doc, types.Chain{}, "")
+}
+
+// unknownAssetListFields returns the sorted fields of an assetlist.json and
+// its assets that the internal types don't know about
+func unknownAssetListFields(file []byte) []string {
+	var doc map[string]skipped
+	var assetList struct {
+		Assets []map[string]skipped `json:"assets"`
+	}
+	if json.Unmarshal(file, &doc) != nil || json.Unmarshal(file, &assetList) != nil {
+		return nil
+	}
+	unknown := unknownFields(doc, types.AssetList{}, "")
+	seen := make(map[string]bool)
+	for _, asset := range assetList.Assets {
+		// type_asset is converted into the kind
+		delete(asset, "type_asset")
+		for _, field := range unknownFields(asset, types.AssetElement{}, "assets.") {
+			if !seen[field] {
+				seen[field] = true
+				unknown = append(unknown, field)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// unknownFields returns the sorted fields of the document that have no
+// counterpart in the json tags of the type
+func unknownFields(doc map[string]skipped, v interface{}, prefix string) []string {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		known[name] = true
+	}
+	unknown := make([]string, 0)
+	for field := range doc {
+		if !known[field] && field != "$schema" {
+			unknown = append(unknown, prefix+field)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
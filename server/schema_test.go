@@ -0,0 +1,107 @@
+package server
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+func TestDecodeChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		version string
+		check   func(t *testing.T, chain types.Chain)
+		unknown []string
+	}{
+		{
+			name:    "v1",
+			file:    `{"chain_name":"osmosis","chain_id":"osmosis-1","genesis":{"genesis_url":"https://example.com/genesis.json"},"slip44":118}`,
+			version: schemaV1,
+			check: func(t *testing.T, chain types.Chain) {
+				if chain.Genesis == nil || chain.Genesis.GenesisURL == nil || *chain.Genesis.GenesisURL != "https://example.com/genesis.json" {
+					t.Errorf("genesis %+v", chain.Genesis)
+				}
+			},
+		},
+		{
+			name: "v2",
+			file: `{"$schema":"../chain.schema.json","chain_name":"osmosis","chain_id":"osmosis-1","slip44":"118","website":"https://osmosis.zone",
+				"codebase":{"genesis":{"genesis_url":"https://example.com/genesis.json"},"binaries":{"linux/amd64":"https://example.com/osmosisd"}}}`,
+			version: schemaV2,
+			check: func(t *testing.T, chain types.Chain) {
+				if chain.Genesis == nil || chain.Genesis.GenesisURL == nil || *chain.Genesis.GenesisURL != "https://example.com/genesis.json" {
+					t.Errorf("genesis wasn't moved out of the codebase: %+v", chain.Genesis)
+				}
+				if chain.Codebase == nil || chain.Codebase.Binaries == nil || chain.Codebase.Binaries.LinuxAMD == nil ||
+					*chain.Codebase.Binaries.LinuxAMD != "https://example.com/osmosisd" {
+					t.Errorf("linux/amd64 binary wasn't mapped: %+v", chain.Codebase)
+				}
+				if chain.Slip44 == nil || *chain.Slip44 != 118 {
+					t.Errorf("slip44 published as a string wasn't parsed: %v", chain.Slip44)
+				}
+			},
+			unknown: []string{"website"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chain, version, err := decodeChain(strings.NewReader(tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version != tc.version {
+				t.Errorf("version %s, want %s", version, tc.version)
+			}
+			if chain.ChainName != "osmosis" || chain.ChainID != "osmosis-1" {
+				t.Errorf("chain %s %s", chain.ChainName, chain.ChainID)
+			}
+			tc.check(t, chain)
+			if tc.unknown == nil {
+				tc.unknown = []string{}
+			}
+			if unknown := unknownChainFields([]byte(tc.file)); !reflect.DeepEqual(unknown, tc.unknown) {
+				t.Errorf("unknown fields %v, want %v", unknown, tc.unknown)
+			}
+		})
+	}
+}
+
+func TestDecodeAssetList(t *testing.T) {
+	file := `{"chain_name":"osmosis","assets":[
+		{"base":"uosmo","display":"osmo","denom_units":[],"type_asset":"sdk.coin"},
+		{"base":"ibc/27394FB0","display":"atom","denom_units":[],"type_asset":"ics20","socials":{},
+		 "traces":[{"type":"ibc","counterparty":{"chain_name":"cosmoshub","base_denom":"uatom","channel_id":"channel-141"},
+		            "chain":{"channel_id":"channel-0","path":"transfer/channel-0/uatom"}}]}]}`
+	assetList, version, err := decodeAssetList(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != schemaV2 {
+		t.Errorf("version %s, want %s", version, schemaV2)
+	}
+	if len(assetList.Assets) != 2 {
+		t.Fatalf("decoded %d assets, want 2", len(assetList.Assets))
+	}
+	osmo, atom := assetList.Assets[0], assetList.Assets[1]
+	if osmo.Kind == nil || *osmo.Kind != types.SDKCoin {
+		t.Errorf("type_asset wasn't mapped to kind: %v", osmo.Kind)
+	}
+	want := &types.Ibc{SourceChannel: "channel-141", DstChannel: "channel-0", SourceDenom: "uatom", SourceChain: "cosmoshub"}
+	if !reflect.DeepEqual(atom.Ibc, want) {
+		t.Errorf("ibc from traces %+v, want %+v", atom.Ibc, want)
+	}
+	trace := types.Trace{
+		Type:         "ibc",
+		Counterparty: types.TraceCounterparty{ChainName: "cosmoshub", BaseDenom: "uatom", ChannelID: "channel-141"},
+		Chain:        &types.TraceChain{ChannelID: "channel-0", Path: "transfer/channel-0/uatom"},
+	}
+	if !reflect.DeepEqual(atom.Traces, []types.Trace{trace}) {
+		t.Errorf("traces %+v, want %+v", atom.Traces, []types.Trace{trace})
+	}
+	if unknown, want := unknownAssetListFields([]byte(file)), []string{"assets.socials", "chain_name"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("unknown fields %v, want %v", unknown, want)
+	}
+}
@@ -2,70 +2,500 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/gorilla/mux"
-	cron "github.com/robfig/cron/v3"
 )
 
+// Option configures optional behaviour of the server
+type Option func(*options)
+
+// defaultPullInterval is how often the registry is pulled unless set with
+// WithPullInterval
+const defaultPullInterval = "@daily"
+
+type options struct {
+	registry      string
+	pullInterval  string
+	logger        *log.Logger
+	client        *http.Client
+	branch        string
+	apiKeys       APIKeys
+	rateLimiter   *RateLimiter
+	updateJitter  time.Duration
+	snapshot      *Snapshot
+	notifiers     []Notifier
+	maxStaleness  time.Duration
+	sharedStore   SharedStore
+	store         Store
+	source        Source
+	pin           string
+	prices        PriceProvider
+	priceInterval time.Duration
+	checkImages   bool
+	imageInterval time.Duration
+	genesisDir    string
+	mirrorDir     string
+	directory     bool
+	signingKey    string
+	readyTimeout  time.Duration
+	readyFallback bool
+	reload        func() ([]Option, error)
+	archive       bool
+	graphql       bool
+	githubToken   string
+	allowChains   []string
+	denyChains    []string
+	aliases       map[string][]string
+	prevChainIDs  map[string][]string
+	indexes       []customIndex
+	routes        []func(router *mux.Router, handler *Handler)
+	certFile      string
+	keyFile       string
+	acme          *ACME
+	adminCAFile   string
+	adminCAs      *x509.CertPool
+	adminBasic    *BasicAuth
+	unixSocket    string
+	timeout       time.Duration
+	limits        Limits
+}
+
+// WithRegistry sets the registry to serve, e.g. cosmos/chain-registry
+func WithRegistry(registry string) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
+
+// WithPullInterval sets how often the registry is pulled as a cron spec, e.g.
+// @daily or @every 1h. Defaults to @daily.
+func WithPullInterval(spec string) Option {
+	return func(o *options) {
+		o.pullInterval = spec
+	}
+}
+
+// WithLogger logs to the logger instead of the standard logger
+func WithLogger(logger *log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithHTTPClient makes every upstream request with the client instead of the
+// one shared by skychart, unless the source, price provider, notifier or ACME
+// config was given its own client
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithAPIKeys enables API key authentication on the restricted route groups
+func WithAPIKeys(keys APIKeys) Option {
+	return func(o *options) {
+		o.apiKeys = keys
+	}
+}
+
+// WithBranch sets the branch of the registry to serve
+func WithBranch(branch string) Option {
+	return func(o *options) {
+		o.branch = branch
+	}
+}
+
+// WithRateLimiter limits the rate of requests each client can make
+func WithRateLimiter(rl *RateLimiter) Option {
+	return func(o *options) {
+		o.rateLimiter = rl
+	}
+}
+
+// WithUpdateJitter delays each scheduled pull by a random duration of up to
+// jitter
+func WithUpdateJitter(jitter time.Duration) Option {
+	return func(o *options) {
+		o.updateJitter = jitter
+	}
+}
+
+// WithSnapshot seeds the server with the snapshot before the initial pull. If
+// the initial pull fails, the server continues to serve the snapshot.
+func WithSnapshot(snapshot Snapshot) Option {
+	return func(o *options) {
+		o.snapshot = &snapshot
+	}
+}
+
+// WithReload lets the server reload its settings on SIGHUP or POST
+// /admin/reload. The options returned by load replace the pull interval,
+// chain filter, aliases, previous chain ids, notifiers and rate limits the
+// server was created with, all other options are ignored until the server is
+// restarted.
+func WithReload(load func() ([]Option, error)) Option {
+	return func(o *options) {
+		o.reload = load
+	}
+}
+
+// WithNotifiers registers notifiers that are called whenever an update
+// changes the registry
+func WithNotifiers(notifiers ...Notifier) Option {
+	return func(o *options) {
+		o.notifiers = append(o.notifiers, notifiers...)
+	}
+}
+
+// WithMaxStaleness sets how old the registry may get, while pulls are
+// failing, before /readyz reports the server as not ready
+func WithMaxStaleness(maxStaleness time.Duration) Option {
+	return func(o *options) {
+		o.maxStaleness = maxStaleness
+	}
+}
+
+// WithSource pulls the registry from the source instead of github
+func WithSource(source Source) Option {
+	return func(o *options) {
+		o.source = source
+	}
+}
+
+// WithArchive pulls the registry from github as a single tarball of the repo
+// instead of fetching the files of every chain separately
+func WithArchive() Option {
+	return func(o *options) {
+		o.archive = true
+	}
+}
+
+// WithGraphQL pulls the files of the registry through github's GraphQL API
+// in a handful of queries. It requires a github token.
+func WithGraphQL() Option {
+	return func(o *options) {
+		o.graphql = true
+	}
+}
+
+// WithGitHubToken authenticates the requests to github, raising its rate
+// limit
+func WithGitHubToken(token string) Option {
+	return func(o *options) {
+		o.githubToken = token
+	}
+}
+
+// WithChainFilter only pulls and serves the chains in allow, if any, that
+// aren't in deny
+func WithChainFilter(allow, deny []string) Option {
+	return func(o *options) {
+		o.allowChains = allow
+		o.denyChains = deny
+	}
+}
+
+// WithAliases sets alternative names that chains and assets can be looked up
+// by, keyed by the chain name or asset display name
+func WithAliases(aliases map[string][]string) Option {
+	return func(o *options) {
+		o.aliases = aliases
+	}
+}
+
+// WithPreviousChainIDs sets chain ids that chains were known by before their
+// current one, keyed by the chain name, e.g. {"cosmoshub": ["cosmoshub-3"]}.
+// Lookups by a previous chain id resolve to the chain with a Warning header.
+func WithPreviousChainIDs(ids map[string][]string) Option {
+	return func(o *options) {
+		o.prevChainIDs = ids
+	}
+}
+
+// WithIndex adds a custom index of the registry, computed whenever the
+// registry changes and served at /v1/indexes/{name}
+func WithIndex(name string, build IndexFunc) Option {
+	return func(o *options) {
+		o.indexes = append(o.indexes, customIndex{name: name, build: build})
+	}
+}
+
+// WithRoutes mounts extra routes on the server's router, behind the same
+// middleware as the built in routes. The handler gives the routes access to
+// the registry and the custom indexes.
+func WithRoutes(register func(router *mux.Router, handler *Handler)) Option {
+	return func(o *options) {
+		o.routes = append(o.routes, register)
+	}
+}
+
+// WithTLS serves https with the certificate and key in the PEM files. The
+// files are reloaded when the certificate changes.
+func WithTLS(certFile, keyFile string) Option {
+	return func(o *options) {
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithAdminBasicAuth lets requests to the admin routes authenticate with HTTP
+// basic auth as an alternative to an API key with the admin scope
+func WithAdminBasicAuth(username, password string) Option {
+	return func(o *options) {
+		o.adminBasic = &BasicAuth{Username: username, Password: password}
+	}
+}
+
+// WithAdminClientCA requires requests to the admin routes to present a client
+// certificate issued by one of the CAs in the PEM file, in addition to any API
+// key. Other routes don't ask for a certificate. Admin requests over plain
+// http, such as through the unix socket, are refused.
+func WithAdminClientCA(caFile string) Option {
+	return func(o *options) {
+		o.adminCAFile = caFile
+	}
+}
+
+// WithACME serves https with a certificate obtained and renewed from an ACME
+// server such as Let's Encrypt
+func WithACME(acme ACME) Option {
+	return func(o *options) {
+		o.acme = &acme
+	}
+}
+
+// WithUnixSocket also serves plain http on a unix socket at path, e.g. for a
+// local reverse proxy. A stale socket left at path is removed.
+func WithUnixSocket(path string) Option {
+	return func(o *options) {
+		o.unixSocket = path
+	}
+}
+
+// WithRequestTimeout answers requests that take longer than timeout with 503
+// Service Unavailable
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithPin serves the registry as of a commit SHA or tag. The registry is
+// pulled once on startup and never updated.
+func WithPin(ref string) Option {
+	return func(o *options) {
+		o.pin = ref
+	}
+}
+
+// WithPrices refreshes the USD price of every asset with a coingecko id from
+// the provider every interval. Prices are included in asset responses when
+// ?include=price is passed.
+func WithPrices(provider PriceProvider, interval time.Duration) Option {
+	return func(o *options) {
+		o.prices = provider
+		o.priceInterval = interval
+	}
+}
+
+// WithImageChecks checks that every logo of the registry resolves every
+// interval, and those added by a pull within a minute. /v1/images then
+// includes the outcome of each check. Defaults to every 24 hours if interval
+// is zero.
+func WithImageChecks(interval time.Duration) Option {
+	return func(o *options) {
+		o.checkImages = true
+		o.imageInterval = interval
+	}
+}
+
+// WithGenesisDir caches the genesis files served by /chain/{chain}/genesis in
+// dir
+func WithGenesisDir(dir string) Option {
+	return func(o *options) {
+		o.genesisDir = dir
+	}
+}
+
+// WithDirectory serves a subset of the cosmos.directory chain API under
+// /directory for clients migrating from that service
+func WithDirectory() Option {
+	return func(o *options) {
+		o.directory = true
+	}
+}
+
+// WithMirror serves the complete tree of the registry, including images and
+// schemas, under /mirror in the layout of the repo. The tree of the last
+// pulled commit is kept in dir, which defaults to a directory in the system's
+// temp dir. It isn't supported with an S3Source.
+func WithMirror(dir string) Option {
+	return func(o *options) {
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "skychart-mirror")
+		}
+		o.mirrorDir = dir
+	}
+}
+
+// WithSigningKey signs the body of every successful response with the
+// Ed25519 key in the PEM file, see Signer. The public key is served at
+// /.well-known/jwks.json.
+func WithSigningKey(keyFile string) Option {
+	return func(o *options) {
+		o.signingKey = keyFile
+	}
+}
+
+// WithReadyAfterPull makes Serve listen before the initial pull instead of
+// once it has succeeded. /readyz responds with 503 until then, while the pull
+// is retried for up to timeout. If it still fails, Serve returns an error
+// unless fallback is set and a registry was restored from the snapshot or
+// store, which is then served.
+func WithReadyAfterPull(timeout time.Duration, fallback bool) Option {
+	return func(o *options) {
+		o.readyTimeout = timeout
+		o.readyFallback = fallback
+	}
+}
+
+// WithStore persists the registry to the store after every pull. On startup
+// the registry is restored from the store, so only the changes since it was
+// saved need to be pulled.
+func WithStore(store Store) Option {
+	return func(o *options) {
+		o.store = store
+	}
+}
+
+// WithSharedStore shares the registry with other replicas through the store.
+// Only one replica at a time pulls from github and saves the registry to the
+// store, from where the other replicas load it.
+func WithSharedStore(store SharedStore) Option {
+	return func(o *options) {
+		o.sharedStore = store
+	}
+}
+
 // Serve starts a server listening on "listenAddr". In parrallel, a cron-like job
 // is also started, pulling the latest registry changes from the provided registry-url
 // This function is blocking and can be stopped by cancelling the provided context.
-func Serve(ctx context.Context, registryUrl, listenAddr, updateFreq string) error {
-	l := log.Default()
-	// Set up the handler and pull in all data
-	handler := NewHandler(registryUrl, l)
-	if err := handler.Pull(ctx); err != nil {
+func Serve(ctx context.Context, registryUrl, listenAddr, updateFreq string, opts ...Option) error {
+	opts = append([]Option{WithRegistry(registryUrl), WithPullInterval(updateFreq)}, opts...)
+	srv, err := New(opts...)
+	if err != nil {
+		return err
+	}
+	if err := srv.Start(ctx); err != nil {
 		return err
 	}
+	defer srv.Stop()
+
+	o, l := srv.opts, srv.log
+	if o.reload != nil {
+		go srv.reloadOnHangup(ctx)
+	}
+	// routes only match GET, so HEAD requests are served as GET requests
+	s := http.Server{Addr: listenAddr, Handler: HeadAsGet(srv.Handler())}
+	o.limits.apply(&s)
+	servers := []*http.Server{&s}
 
-	// create a router to handle inbound requests
-	router := mux.NewRouter()
-	router.HandleFunc("/", Ok).Methods("GET")
-	// use some form of versioning to allow for future changes
-	v1Router := router.PathPrefix("/v1").Subrouter()
-	v1Router.HandleFunc("/chains", handler.Chains).Methods("GET")
-	v1Router.HandleFunc("/chain/{chain}", handler.Chain).Methods("GET")
-	v1Router.HandleFunc("/chain/{chain}/endpoints/{type}", handler.Endpoints).Methods("GET")
-	v1Router.HandleFunc("/chain/{chain}/assets", handler.ChainAsset).Methods("GET")
-	v1Router.HandleFunc("/assets", handler.Assets).Methods("GET")
-	v1Router.HandleFunc("/asset/{asset}", handler.Asset).Methods("GET")
-	s := http.Server{Addr: listenAddr, Handler: router}
-
-	errs := make(chan error, 1)
+	errs := make(chan error, 4)
+	if srv.initial != nil {
+		go func() {
+			if err := <-srv.initial; err != nil && ctx.Err() == nil {
+				errs <- err
+			}
+		}()
+	}
+	if o.unixSocket != "" {
+		if err := os.Remove(o.unixSocket); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		listener, err := o.limits.listen("unix", o.unixSocket)
+		if err != nil {
+			return err
+		}
+		unix := &http.Server{Handler: s.Handler}
+		o.limits.apply(unix)
+		servers = append(servers, unix)
+		go func() {
+			errs <- unix.Serve(listener)
+		}()
+		l.Printf("server up on %s", o.unixSocket)
+	}
+	switch {
+	case o.acme != nil:
+		// certificates are obtained on the first handshake, answering the
+		// challenges on the http address
+		manager := o.acme.manager()
+		challenges := &http.Server{Addr: o.acme.httpAddr(), Handler: manager.HTTPHandler(nil)}
+		o.limits.apply(challenges)
+		servers = append(servers, challenges)
+		go func() {
+			errs <- challenges.ListenAndServe()
+		}()
+		s.TLSConfig = &tls.Config{GetCertificate: manager.GetCertificate}
+		o.requestAdminCerts(s.TLSConfig)
+	case o.certFile != "":
+		certs, err := newCertFiles(o.certFile, o.keyFile)
+		if err != nil {
+			return err
+		}
+		s.TLSConfig = &tls.Config{GetCertificate: certs.GetCertificate}
+		o.requestAdminCerts(s.TLSConfig)
+	}
+
+	addr := listenAddr
+	if addr == "" {
+		addr = ":http"
+		if s.TLSConfig != nil {
+			addr = ":https"
+		}
+	}
+	listener, err := o.limits.listen("tcp", addr)
+	if err != nil {
+		for _, server := range servers {
+			server.Close()
+		}
+		return err
+	}
 	go func() {
 		// If there is an error on startup catch it and pass it through
 		// the channel
-		errs <- s.ListenAndServe()
+		if s.TLSConfig != nil {
+			errs <- s.ServeTLS(listener, "", "")
+		} else {
+			errs <- s.Serve(listener)
+		}
 	}()
 
 	l.Printf("server up on %s", s.Addr)
 
-	crawler := cron.New(cron.WithLogger(cron.PrintfLogger(l)))
-	crawler.AddFunc(updateFreq, func() {
-		// update the servers local records
-		if err := handler.Pull(ctx); err != nil {
-			l.Print(err)
-		}
-	})
-	crawler.Start()
-	defer crawler.Stop()
-
-	l.Printf("cron scheduler running with update frequency: %s", updateFreq)
-
 	select {
 	// Use contexts to manage the servers lifecycle
 	case <-ctx.Done():
-		// This will stop the other go routine if it hasn't already
+		// This will stop the other go routines if they haven't already
 		// stopped yet
 		l.Print("shutting down server")
-		if err := s.Close(); err != nil {
-			return err
+		for _, server := range servers {
+			if err := server.Close(); err != nil {
+				return err
+			}
 		}
 		err := <-errs
 		return err
 	case err := <-errs:
+		for _, server := range servers {
+			server.Close()
+		}
 		return err
 	}
 }
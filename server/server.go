@@ -0,0 +1,347 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Server serves the chain registry. Unlike Serve, it doesn't listen itself so
+// that other Go services can embed skychart by mounting its Handler on their
+// own server:
+//
+//	srv, err := server.New(server.WithRegistry("cosmos/chain-registry"))
+//	if err != nil {
+//		return err
+//	}
+//	if err := srv.Start(ctx); err != nil {
+//		return err
+//	}
+//	defer srv.Stop()
+//	mux.Handle("/skychart/", http.StripPrefix("/skychart", srv.Handler()))
+type Server struct {
+	handler *Handler
+	router  *mux.Router
+	opts    options
+	log     *log.Logger
+
+	// mtx guards the lifecycle of the server below
+	mtx     sync.Mutex
+	updater *scheduler
+	cancel  context.CancelFunc
+	// initial receives the outcome of the initial pull when it runs in the
+	// background, see WithReadyAfterPull
+	initial chan error
+}
+
+// New creates a server for the registry given by WithRegistry. The registry is
+// empty until Start is called.
+func New(opts ...Option) (*Server, error) {
+	o := options{pullInterval: defaultPullInterval, limits: DefaultLimits}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.registry == "" {
+		return nil, errors.New("no registry set")
+	}
+	if o.client != nil {
+		o.useHTTPClient(o.client)
+	}
+	if o.mirrorDir != "" {
+		if _, ok := o.source.(S3Source); ok {
+			return nil, errors.New("an S3 source can't be mirrored")
+		}
+	}
+	if o.adminCAFile != "" {
+		pool, err := loadCertPool(o.adminCAFile)
+		if err != nil {
+			return nil, err
+		}
+		o.adminCAs = pool
+	}
+	var signer *Signer
+	if o.signingKey != "" {
+		var err error
+		if signer, err = LoadSigner(o.signingKey); err != nil {
+			return nil, err
+		}
+	}
+	l := o.logger
+	if l == nil {
+		l = log.Default()
+	}
+
+	handler := NewHandler(o.registry, l)
+	if o.branch != "" {
+		handler.SetBranch(o.branch)
+	}
+	handler.SetMaxStaleness(o.maxStaleness)
+	if o.pin != "" {
+		handler.Pin(o.pin)
+	}
+	if o.source != nil {
+		handler.SetSource(o.source)
+	}
+	if o.client != nil {
+		handler.SetHTTPClient(o.client)
+	}
+	handler.SetArchive(o.archive)
+	handler.SetGraphQL(o.graphql)
+	handler.SetGitHubToken(o.githubToken)
+	handler.SetChainFilter(o.allowChains, o.denyChains)
+	handler.SetAliases(o.aliases)
+	handler.SetPreviousChainIDs(o.prevChainIDs)
+	for _, index := range o.indexes {
+		handler.AddIndex(index.name, index.build)
+	}
+	if o.genesisDir != "" {
+		handler.SetGenesisDir(o.genesisDir)
+	}
+	if o.mirrorDir != "" {
+		handler.SetMirror(o.mirrorDir)
+	}
+	// notifiers from the options are kept apart from those added later, as
+	// Reload replaces them
+	handler.optNotifiers = o.notifiers
+
+	// create a router to handle inbound requests
+	router := mux.NewRouter()
+	// panics are recovered outside of the other middleware so that none of
+	// them can take down the connection
+	router.Use(handler.Recover)
+	// requests refused by the rate limiter are counted too
+	router.Use(handler.CountUsage(o.apiKeys))
+	if o.rateLimiter != nil {
+		router.Use(o.rateLimiter.Middleware)
+	}
+	if o.timeout > 0 {
+		router.Use(Timeout(o.timeout))
+	}
+	useRegistryMiddleware(router, handler)
+	// the body is signed as it is sent, after any rewrite below
+	if signer != nil {
+		router.Use(signer.Middleware)
+		router.HandleFunc("/.well-known/jwks.json", signer.JWKS).Methods("GET")
+	}
+	// fields are projected before the response is indented
+	router.Use(PrettyJSON)
+	router.Use(FieldProjection)
+	router.HandleFunc("/", Ok).Methods("GET")
+	router.HandleFunc("/status", handler.Status).Methods("GET")
+	router.HandleFunc("/readyz", handler.Readyz).Methods("GET")
+	router.HandleFunc("/metrics", handler.Metrics).Methods("GET")
+	router.HandleFunc("/version", BuildInfo).Methods("GET")
+	// use some form of versioning to allow for future changes
+	registerRoutes(router, handler, o)
+	// mirror the cosmos.directory API for clients migrating from that service
+	if o.directory {
+		handler.DirectoryRoutes(router.PathPrefix("/directory").Subrouter())
+	}
+	for _, register := range o.routes {
+		register(router, handler)
+	}
+
+	s := &Server{handler: handler, router: router, opts: o, log: l}
+	handler.reload = s.reload
+	return s, nil
+}
+
+// Handler returns the http.Handler serving the API. Routes only match GET
+// requests, wrap it with HeadAsGet to also answer HEAD requests.
+func (s *Server) Handler() http.Handler {
+	return s.router
+}
+
+// Start restores the registry from the snapshot or store, if any, pulls it and
+// schedules further pulls until Stop is called or the context is cancelled.
+// It returns an error if the initial pull fails and there is no restored
+// registry to serve instead, unless WithReadyAfterPull runs the initial pull
+// in the background. A stopped server can be started again.
+func (s *Server) Start(ctx context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.cancel != nil {
+		return errors.New("server already started")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	if err := s.start(ctx); err != nil {
+		s.stop()
+		return err
+	}
+	return nil
+}
+
+// Pull pulls the registry now rather than waiting for the next scheduled
+// pull. If a pull is already running, it waits for that one instead.
+func (s *Server) Pull(ctx context.Context) error {
+	if err := s.handler.Pull(ctx); err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	updater := s.updater
+	s.mtx.Unlock()
+	if updater != nil {
+		updater.saved(ctx)
+	}
+	return nil
+}
+
+func (s *Server) start(ctx context.Context) error {
+	o, l, handler := s.opts, s.log, s.handler
+	// seed the registry from the snapshot or the store, if any, so that
+	// the initial pull only fetches recent changes
+	restored := o.snapshot != nil
+	if o.snapshot != nil {
+		if err := handler.Restore(*o.snapshot); err != nil {
+			return err
+		}
+	} else if o.store != nil {
+		snapshot, err := o.store.Load(ctx)
+		if err != nil {
+			return err
+		}
+		// a registry saved from another branch or ref would never be
+		// replaced by the pull
+		if !snapshot.Timestamp.IsZero() && snapshot.Branch == handler.branch {
+			// changes were notified before the registry was saved
+			if err := handler.restore(snapshot, false); err != nil {
+				return err
+			}
+			restored = true
+		}
+	}
+	var rep *replica
+	pull := true
+	if o.sharedStore != nil {
+		rep = newReplica(o.sharedStore, handler, l)
+		// if another replica is pulling, only pull if it hasn't shared the
+		// registry yet
+		if !rep.sync(ctx) {
+			pull = handler.updated().Equal(time.Unix(0, 0))
+		}
+		go rep.run(ctx)
+		// a follower only ever serves the registry of its primary
+		if primary, ok := o.sharedStore.(*Primary); ok {
+			pull = false
+			if handler.updated().Equal(time.Unix(0, 0)) {
+				l.Printf("waiting for the registry of %s", primary.URL)
+			}
+		}
+	}
+	s.updater = newScheduler(handler, o.updateJitter, rep, o.store, l)
+	if pull && o.readyTimeout > 0 {
+		handler.mtx.Lock()
+		handler.awaitingPull = true
+		handler.mtx.Unlock()
+		s.initial = make(chan error, 1)
+		go func(updater *scheduler) {
+			s.initial <- s.awaitPull(ctx, updater, restored)
+		}(s.updater)
+	} else if pull {
+		if err := handler.Pull(ctx); err != nil {
+			if !restored {
+				return err
+			}
+			l.Printf("initial pull failed, serving restored registry: %v", err)
+		} else {
+			s.updater.saved(ctx)
+		}
+	}
+
+	if o.pin != "" {
+		l.Printf("serving registry pinned to %s, updates are disabled", o.pin)
+	} else {
+		if err := s.updater.schedule(ctx, o.pullInterval); err != nil {
+			return err
+		}
+		handler.mtx.Lock()
+		handler.nextPull = s.updater.next
+		handler.mtx.Unlock()
+		l.Printf("cron scheduler running with update frequency: %s", o.pullInterval)
+	}
+
+	if o.prices != nil {
+		interval := o.priceInterval
+		if interval <= 0 {
+			interval = DefaultPriceInterval
+		}
+		go handler.updatePrices(ctx, o.prices, interval)
+	}
+	if o.checkImages {
+		interval := o.imageInterval
+		if interval <= 0 {
+			interval = DefaultImageCheckInterval
+		}
+		go handler.checkImages(ctx, interval)
+	}
+	return nil
+}
+
+// awaitPull retries the initial pull until it succeeds or the ready timeout
+// elapses, when the restored registry is served if the options allow it.
+// The server reports itself as ready once either happens.
+func (s *Server) awaitPull(ctx context.Context, updater *scheduler, restored bool) error {
+	o, l, handler := s.opts, s.log, s.handler
+	deadline := time.Now().Add(o.readyTimeout)
+	retry := time.Second
+	for {
+		pullCtx, cancel := context.WithDeadline(ctx, deadline)
+		err := handler.Pull(pullCtx)
+		cancel()
+		if err == nil {
+			updater.saved(ctx)
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if time.Now().Add(retry).After(deadline) {
+			if !restored || !o.readyFallback {
+				return fmt.Errorf("initial pull didn't succeed within %s: %w", o.readyTimeout, err)
+			}
+			l.Printf("initial pull didn't succeed within %s, serving restored registry: %v", o.readyTimeout, err)
+			break
+		}
+		l.Printf("initial pull failed, retrying in %s: %v", retry, err)
+		select {
+		case <-time.After(retry):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if retry *= 2; retry > time.Minute {
+			retry = time.Minute
+		}
+	}
+	handler.mtx.Lock()
+	handler.awaitingPull = false
+	handler.mtx.Unlock()
+	return nil
+}
+
+// Stop stops pulling the registry, cancelling a pull that is running. The
+// registry continues to be served as it was.
+func (s *Server) Stop() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.stop()
+}
+
+func (s *Server) stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if s.updater != nil {
+		s.updater.stop()
+	}
+	s.handler.mtx.Lock()
+	s.handler.nextPull = nil
+	s.handler.mtx.Unlock()
+	s.updater, s.cancel = nil, nil
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// how often a replica renews its lease or checks for a newer snapshot
+	syncInterval = 30 * time.Second
+	// a lease that isn't renewed for this long passes to another replica
+	leaseTTL = 3 * syncInterval
+)
+
+// SharedStore holds the registry for a group of replicas serving the same
+// registry. Only the replica holding the lease pulls from github, the others
+// serve the snapshot it saves to the store.
+type SharedStore interface {
+	Store
+	// Lead acquires or renews the lease for the replica id. It returns true
+	// if the replica holds the lease.
+	Lead(ctx context.Context, id string, ttl time.Duration) (bool, error)
+	// Updated returns the timestamp of the shared snapshot, or the zero time
+	// if there is none
+	Updated(ctx context.Context) (time.Time, error)
+}
+
+// replica keeps a handler in sync with the other replicas sharing a store
+type replica struct {
+	id      string
+	store   SharedStore
+	handler *Handler
+	log     *log.Logger
+
+	mtx    sync.Mutex
+	leader bool
+}
+
+func newReplica(store SharedStore, handler *Handler, log *log.Logger) *replica {
+	hostname, _ := os.Hostname()
+	return &replica{
+		id:      fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano()),
+		store:   store,
+		handler: handler,
+		log:     log,
+	}
+}
+
+// sync renews the replica's lease and, if another replica holds it, restores
+// the shared snapshot when it is newer than the handler's registry. It
+// returns whether the replica is the leader.
+func (r *replica) sync(ctx context.Context) bool {
+	leader, err := r.store.Lead(ctx, r.id, leaseTTL)
+	if err != nil {
+		r.log.Printf("renewing lease: %v", err)
+	}
+
+	r.mtx.Lock()
+	if leader != r.leader {
+		if leader {
+			r.log.Printf("replica %s is now pulling the registry", r.id)
+		} else {
+			r.log.Printf("replica %s is no longer pulling the registry", r.id)
+		}
+	}
+	r.leader = leader
+	r.mtx.Unlock()
+
+	if leader {
+		return true
+	}
+
+	updated, err := r.store.Updated(ctx)
+	if err != nil {
+		r.log.Printf("checking shared snapshot: %v", err)
+		return false
+	}
+	if !updated.After(r.handler.updated()) {
+		return false
+	}
+	snapshot, err := r.store.Load(ctx)
+	if err != nil {
+		r.log.Printf("loading shared snapshot: %v", err)
+		return false
+	}
+	// the leader has already notified of any changes
+	if err := r.handler.restore(snapshot, false); err != nil {
+		r.log.Printf("restoring shared snapshot: %v", err)
+	}
+	return false
+}
+
+func (r *replica) isLeader() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.leader
+}
+
+// publish saves the handler's registry to the store for the other replicas
+func (r *replica) publish(ctx context.Context) {
+	if err := r.store.Save(ctx, r.handler.Snapshot()); err != nil {
+		r.log.Printf("saving shared snapshot: %v", err)
+	}
+}
+
+// run syncs the replica until the context is cancelled
+func (r *replica) run(ctx context.Context) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.sync(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// signatureHeader carries the detached JWS of the response body
+const signatureHeader = "X-Skychart-Signature"
+
+// Signer signs the bodies of responses with an Ed25519 key, so that clients
+// of a skychart hosted by a third party can verify that the data comes from
+// the holder of the key. Each signature is a JWS (RFC 7515) in the compact
+// serialization with a detached payload: the body of the response.
+type Signer struct {
+	key ed25519.PrivateKey
+	kid string
+	// protected is the encoded JWS header, which is the same for every response
+	protected string
+}
+
+// LoadSigner reads a PKCS #8 PEM encoded Ed25519 private key, as generated
+// by openssl genpkey -algorithm ed25519
+func LoadSigner(keyFile string) (*Signer, error) {
+	bz, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(bz)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in %s", keyFile)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", keyFile, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("the signing key must be an Ed25519 key")
+	}
+	return NewSigner(key), nil
+}
+
+// NewSigner returns a signer of the key. The key id is the JWK thumbprint
+// (RFC 7638) of the public key.
+func NewSigner(key ed25519.PrivateKey) *Signer {
+	x := base64.RawURLEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+	thumbprint := sha256.Sum256([]byte(`{"crv":"Ed25519","kty":"OKP","x":"` + x + `"}`))
+	kid := base64.RawURLEncoding.EncodeToString(thumbprint[:])
+	protected, _ := json.Marshal(map[string]string{"alg": "EdDSA", "kid": kid})
+	return &Signer{key: key, kid: kid, protected: base64.RawURLEncoding.EncodeToString(protected)}
+}
+
+// Sign returns the detached JWS of the payload
+func (s *Signer) Sign(payload []byte) string {
+	input := s.protected + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature := ed25519.Sign(s.key, []byte(input))
+	return s.protected + ".." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// Middleware adds the signature of the body to successful responses.
+// Streaming routes are left unsigned as they are too large to buffer. Genesis
+// files can be checked against their published sha256 instead.
+func (s *Signer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if streaming(req) {
+			next.ServeHTTP(res, req)
+			return
+		}
+		buf := &bufferedWriter{ResponseWriter: res, status: http.StatusOK}
+		next.ServeHTTP(buf, req)
+
+		body := buf.body.Bytes()
+		if buf.status == http.StatusOK {
+			res.Header().Set(signatureHeader, s.Sign(body))
+		}
+		res.WriteHeader(buf.status)
+		_, _ = res.Write(body)
+	})
+}
+
+// JWKS serves the public key as a JSON Web Key Set, for clients to verify the
+// signatures with
+func (s *Signer) JWKS(res http.ResponseWriter, req *http.Request) {
+	respondWithJSON(res, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey)),
+			"kid": s.kid,
+			"alg": "EdDSA",
+			"use": "sig",
+		}},
+	})
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// maxSnapshotBody bounds the size of a snapshot imported through /admin/import,
+// well above the size of the chain registry
+const maxSnapshotBody = 128 << 20
+
+// Snapshot is a copy of the entire registry held by a handler. It can be
+// exported and restored to copy state between servers or to seed a server
+// without pulling from github.
+type Snapshot struct {
+	Registry   string                     `json:"registry"`
+	Branch     string                     `json:"branch"`
+	Timestamp  time.Time                  `json:"timestamp"`
+	Commit     string                     `json:"commit,omitempty"`
+	Chains     map[string]types.Chain     `json:"chains"`
+	AssetLists map[string]types.AssetList `json:"asset_lists"`
+	// Tombstones records the chains removed from the registry
+	Tombstones map[string]types.Tombstone `json:"tombstones,omitempty"`
+}
+
+// Snapshot returns a copy of the handler's registry
+func (h *Handler) Snapshot() Snapshot {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	snapshot := Snapshot{
+		Registry:   h.registryUrl,
+		Branch:     h.branch,
+		Timestamp:  h.lastUpdated,
+		Commit:     h.commit,
+		Chains:     make(map[string]types.Chain, len(h.chainList)),
+		AssetLists: make(map[string]types.AssetList, len(h.assetList)),
+		Tombstones: make(map[string]types.Tombstone, len(h.tombstones)),
+	}
+	for name, tombstone := range h.tombstones {
+		snapshot.Tombstones[name] = tombstone
+	}
+	for name, chain := range h.chainList {
+		snapshot.Chains[name] = chain
+	}
+	for name, assetList := range h.assetList {
+		snapshot.AssetLists[name] = assetList
+	}
+	return snapshot
+}
+
+// Restore replaces the handler's registry with the snapshot. The snapshot's
+// timestamp becomes the last updated time so the next pull only fetches
+// changes made after the snapshot was taken.
+func (h *Handler) Restore(snapshot Snapshot) error {
+	return h.restore(snapshot, true)
+}
+
+func (h *Handler) restore(snapshot Snapshot, notify bool) error {
+	if snapshot.Registry != "" && snapshot.Registry != h.registryUrl {
+		return fmt.Errorf("snapshot is of registry %s, expected %s", snapshot.Registry, h.registryUrl)
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	chains, assetLists, lastUpdated := h.chainList, h.assetList, h.lastUpdated
+	h.chainList = make(map[string]types.Chain, len(snapshot.Chains))
+	for name, chain := range snapshot.Chains {
+		if h.filter.allowed(name) {
+			h.chainList[name] = chain
+		}
+	}
+	h.assetList = make(map[string]types.AssetList, len(snapshot.AssetLists))
+	for name, assetList := range snapshot.AssetLists {
+		if h.filter.allowed(name) {
+			h.assetList[name] = assetList
+		}
+	}
+	// snapshots only hold the typed registry
+	h.files = make(map[string][]byte)
+	h.fileHashes = make(map[string]string)
+	h.lastUpdated = snapshot.Timestamp
+	h.commit = snapshot.Commit
+	h.tombstones = make(map[string]types.Tombstone, len(snapshot.Tombstones))
+	for name, tombstone := range snapshot.Tombstones {
+		h.tombstones[name] = tombstone
+	}
+	h.index()
+	h.cache.reset()
+	now := time.Now()
+	if h.recordChanges(chains, assetLists, now) > 0 && notify {
+		h.notify(h.changesSince(lastUpdated))
+	}
+	h.recordHistory(chains, assetLists, now)
+	return nil
+}
+
+// updated returns when the registry was last updated
+func (h *Handler) updated() time.Time {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	return h.lastUpdated
+}
+
+// index rebuilds the chain and asset indexes from the chain and asset lists.
+// The caller must hold the write lock.
+func (h *Handler) index() {
+	h.chains = make([]string, 0, len(h.chainList))
+	h.chainById = make(map[string]string, len(h.chainList))
+	h.chainByKey = make(map[string]string, 2*len(h.chainList))
+	for name, chain := range h.chainList {
+		h.chains = append(h.chains, name)
+		h.chainById[chain.ChainID] = name
+		h.chainByKey[strings.ToLower(chain.ChainID)] = name
+	}
+	sort.Strings(h.chains)
+	// if chains share a network, the first in alphabetical order wins
+	h.chainByNet = make(map[string]string, len(h.chains))
+	for _, name := range h.chains {
+		network := strings.ToLower(chainNetwork(h.chainList[name].ChainID))
+		if _, ok := h.chainByNet[network]; !ok {
+			h.chainByNet[network] = name
+		}
+	}
+
+	// assets of different chains can share a display name, so every chain
+	// of an asset is indexed
+	names := make([]string, 0, len(h.assetList))
+	for name := range h.assetList {
+		names = append(names, name)
+		h.chainByKey[strings.ToLower(name)] = name
+	}
+	sort.Strings(names)
+	h.assetChains = make(map[string][]string)
+	h.assetByKey = make(map[string]string)
+	for _, name := range names {
+		for _, asset := range h.assetList[name].Assets {
+			chains := h.assetChains[asset.Display]
+			if len(chains) == 0 || chains[len(chains)-1] != name {
+				h.assetChains[asset.Display] = append(chains, name)
+			}
+			h.assetByKey[strings.ToLower(asset.Display)] = asset.Display
+		}
+	}
+	// lookups that expect a single asset resolve to the chain it is native
+	// to, or otherwise the first chain in alphabetical order
+	h.assets = make([]string, 0, len(h.assetChains))
+	h.chainByAsset = make(map[string]string, len(h.assetChains))
+	for display, chains := range h.assetChains {
+		sort.SliceStable(chains, func(i, j int) bool {
+			return h.isNative(chains[i], display) && !h.isNative(chains[j], display)
+		})
+		h.assets = append(h.assets, display)
+		h.chainByAsset[display] = chains[0]
+	}
+	// names take precedence over the ids of other chains
+	for name := range h.chainList {
+		h.chainByKey[strings.ToLower(name)] = name
+	}
+	sort.Strings(h.assets)
+
+	h.indexAliases()
+	h.indexPreviousChainIDs()
+	h.buildIndexes()
+}
+
+// Export responds with a snapshot of the entire registry
+func (h *Handler) Export(res http.ResponseWriter, req *http.Request) {
+	snapshot := h.Snapshot()
+	res.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=skychart-%d.json", snapshot.Timestamp.Unix()))
+	respondWithJSON(res, snapshot)
+}
+
+// Import replaces the registry with the snapshot in the request body, which
+// can be at most maxSnapshotBody long
+func (h *Handler) Import(res http.ResponseWriter, req *http.Request) {
+	var snapshot Snapshot
+	body := http.MaxBytesReader(res, req.Body, maxSnapshotBody)
+	if err := json.NewDecoder(body).Decode(&snapshot); err != nil {
+		h.log.Printf("decoding imported snapshot: %v", err)
+		badRequest(res)
+		return
+	}
+	if err := h.Restore(snapshot); err != nil {
+		h.log.Print(err)
+		badRequest(res)
+		return
+	}
+	h.log.Printf("imported registry snapshot from %s (%d chains)", snapshot.Timestamp.String(), len(snapshot.Chains))
+	res.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+)
+
+// the keys that the chain and asset lists can be sorted by
+const (
+	sortByName    = "name"
+	sortByChainID = "chain_id"
+	sortByAssets  = "assets"
+	sortByUpdated = "updated"
+)
+
+// parseSort returns the key given by ?sort=, which defaults to the name if only
+// ?order= is given, and whether the order is descending. It returns false if
+// the order isn't asc or desc.
+func parseSort(req *http.Request) (string, bool, bool) {
+	query := req.URL.Query()
+	key, order := query.Get("sort"), query.Get("order")
+	if key == "" && order != "" {
+		key = sortByName
+	}
+	switch order {
+	case "", "asc":
+		return key, false, true
+	case "desc":
+		return key, true, true
+	default:
+		return "", false, false
+	}
+}
+
+// sortedChains returns the chain names sorted by key. Chains with equal keys
+// are sorted by name. It returns false if the chains can't be sorted by key.
+// The caller must hold the read lock.
+func (h *Handler) sortedChains(key string, desc bool) ([]string, bool) {
+	var less func(a, b string) bool
+	switch key {
+	case sortByName:
+		less = func(a, b string) bool { return a < b }
+	case sortByChainID:
+		less = func(a, b string) bool { return h.chainList[a].ChainID < h.chainList[b].ChainID }
+	case sortByAssets:
+		less = func(a, b string) bool { return len(h.assetList[a].Assets) < len(h.assetList[b].Assets) }
+	case sortByUpdated:
+		less = func(a, b string) bool { return h.chainUpdated(a).Before(h.chainUpdated(b)) }
+	default:
+		return nil, false
+	}
+	return sortNames(h.chains, less, desc), true
+}
+
+// sortedAssets returns the asset names sorted by key, where the chain ID and
+// last update are those of the asset's chain. Assets with equal keys are
+// sorted by name. It returns false if the assets can't be sorted by key. The
+// caller must hold the read lock.
+func (h *Handler) sortedAssets(key string, desc bool) ([]string, bool) {
+	var less func(a, b string) bool
+	switch key {
+	case sortByName:
+		less = func(a, b string) bool { return a < b }
+	case sortByChainID:
+		less = func(a, b string) bool {
+			return h.chainList[h.chainByAsset[a]].ChainID < h.chainList[h.chainByAsset[b]].ChainID
+		}
+	case sortByUpdated:
+		less = func(a, b string) bool {
+			return h.chainUpdated(h.chainByAsset[a]).Before(h.chainUpdated(h.chainByAsset[b]))
+		}
+	default:
+		return nil, false
+	}
+	return sortNames(h.assets, less, desc), true
+}
+
+// sortNames sorts a copy of the names, which must already be sorted by name
+func sortNames(names []string, less func(a, b string) bool, desc bool) []string {
+	sorted := make([]string, len(names))
+	copy(sorted, names)
+	if desc {
+		sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[j], sorted[i]) })
+	} else {
+		sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	}
+	return sorted
+}
+
+// chainUpdated returns when the chain.json or assetlist.json of a chain last
+// changed. The caller must hold the read lock.
+func (h *Handler) chainUpdated(name string) time.Time {
+	var updated time.Time
+	history := h.history[name]
+	if n := len(history.Chain); n > 0 {
+		updated = history.Chain[n-1].Timestamp
+	}
+	if n := len(history.AssetList); n > 0 && history.AssetList[n-1].Timestamp.After(updated) {
+		updated = history.AssetList[n-1].Timestamp
+	}
+	return updated
+}
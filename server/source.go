@@ -0,0 +1,171 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Source is where the registry is pulled from. The registry is laid out as a
+// directory per chain, each containing a chain.json and assetlist.json.
+type Source interface {
+	// Changed returns true if the registry has changed since the given time
+	Changed(ctx context.Context, since time.Time) (bool, error)
+	// Chains returns the names of the chain directories
+	Chains(ctx context.Context) ([]string, error)
+	// File returns the contents of a file in a chain's directory. It returns
+	// false if the file doesn't exist.
+	File(ctx context.Context, chain, file string) ([]byte, bool, error)
+}
+
+// streamSource is implemented by sources that can stream a file from upstream,
+// so that it is decoded as it arrives instead of after it was read. Pulls still
+// keep every file as pulled. Sources that hold the files in memory anyway, such
+// as the tarball and GraphQL batches of github, or S3, whose responses are read
+// whole, don't stream. The caller closes the reader.
+type streamSource interface {
+	OpenFile(ctx context.Context, chain, file string) (io.ReadCloser, bool, error)
+}
+
+// openFile streams the file from the source if it supports it, or otherwise
+// reads it into memory. It returns false if the file doesn't exist.
+func openFile(ctx context.Context, source Source, chain, file string) (io.ReadCloser, bool, error) {
+	if streaming, ok := source.(streamSource); ok {
+		return streaming.OpenFile(ctx, chain, file)
+	}
+	return readerOf(source.File(ctx, chain, file))
+}
+
+// readerOf wraps the file returned by a Source in a reader
+func readerOf(bz []byte, found bool, err error) (io.ReadCloser, bool, error) {
+	if err != nil || !found {
+		return nil, found, err
+	}
+	return io.NopCloser(bytes.NewReader(bz)), true, nil
+}
+
+// commitSource is implemented by sources that are versioned by commits
+type commitSource interface {
+	// Commit returns the SHA of the latest commit
+	Commit(ctx context.Context) (string, error)
+}
+
+// pinnableSource is implemented by sources versioned by commits that can read
+// the registry as of a commit
+type pinnableSource interface {
+	// At returns the source reading the files of the registry at the commit
+	// rather than at the head of the branch
+	At(commit string) Source
+}
+
+// treeSource is implemented by sources that can write the complete tree of
+// the registry, including the images and schemas, for the mirror
+type treeSource interface {
+	// Tree writes every file of the registry at the commit into dir
+	Tree(ctx context.Context, commit, dir string) error
+}
+
+// rootSource is implemented by sources that can read the files at the root of
+// the registry, outside of the chain directories, such as its JSON schemas
+type rootSource interface {
+	// RootFile returns the contents of a file at the root of the registry at
+	// the commit, or at the head of the branch if the commit is empty.
+	// Sources that aren't versioned by commits ignore it. It returns false if
+	// the file doesn't exist.
+	RootFile(ctx context.Context, commit, file string) ([]byte, bool, error)
+}
+
+// prefetchSource is implemented by sources that fetch the files of many
+// chains at once. Prefetch is called with the chains to pull before their
+// files are requested.
+type prefetchSource interface {
+	Prefetch(ctx context.Context, chains []string) error
+}
+
+// SetSource sets the source the registry is pulled from instead of the
+// github repo
+func (h *Handler) SetSource(source Source) {
+	h.source = source
+}
+
+// registrySource returns the source set with SetSource or otherwise the
+// github repo of the registry. The caller must hold the read lock.
+func (h *Handler) registrySource() Source {
+	if h.source != nil {
+		return h.source
+	}
+	source := githubSource{
+		repo:   h.registryUrl,
+		branch: h.branch,
+		apiUrl: h.apiUrl,
+		rawUrl: h.rawUrl,
+		token:  h.githubToken,
+		quota:  h.quota,
+		client: h.fetches.client(h.client),
+	}
+	// each pull downloads a fresh tarball or set of blobs
+	if h.archive {
+		source.archive = &archive{}
+	} else if h.graphql && h.githubToken != "" {
+		source.blobs = newBlobs()
+	}
+	return source
+}
+
+// isChainDir returns false for directories of the registry that don't
+// contain mainnet chains
+func isChainDir(name string) bool {
+	return !strings.Contains(name, "testnets") && !strings.Contains(name, ".")
+}
+
+// fetch returns the body of the response to query, or false if the server
+// responded with 404 Not Found. If set, observe is called with the headers
+// of the response.
+func fetch(ctx context.Context, client *http.Client, header http.Header, query string, observe func(http.Header)) ([]byte, bool, error) {
+	body, found, err := stream(ctx, client, header, query, observe)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	defer body.Close()
+	bz, err := io.ReadAll(body)
+	if err != nil {
+		return nil, false, err
+	}
+	return bz, true, nil
+}
+
+// stream is fetch without reading the body, which the caller closes
+func stream(ctx context.Context, client *http.Client, header http.Header, query string, observe func(http.Header)) (io.ReadCloser, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, query, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	if observe != nil {
+		observe(resp.Header)
+	}
+
+	if err := rateLimited(resp); err != nil {
+		resp.Body.Close()
+		return nil, false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, false, fmt.Errorf("unexpected status code for query %s: %d", query, resp.StatusCode)
+	}
+	return resp.Body, true, nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// ChainStaking returns the staking tokens of a chain and how long they are
+// locked after unbonding. Chains that don't publish their staking section
+// respond with an empty one.
+func (h *Handler) ChainStaking(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+
+	exists, chain := h.findChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	var staking types.Staking
+	if chain.Staking != nil {
+		staking = *chain.Staking
+	}
+	if staking.StakingTokens == nil {
+		staking.StakingTokens = []types.StakingTokenElement{}
+	}
+	h.respond(res, req, staking)
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const unknown = "unknown"
+
+// Stats returns the number of chains and assets and when the registry was
+// last updated
+func (h *Handler) Stats(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	// assets that share a display name across chains are counted separately
+	assets := 0
+	for _, assetList := range h.assetList {
+		assets += len(assetList.Assets)
+	}
+	h.respond(res, req, types.Stats{
+		Chains:      len(h.chains),
+		Assets:      assets,
+		LastUpdated: h.lastUpdated,
+		Commit:      h.commit,
+	})
+}
+
+// RegistryStats returns aggregate counts of the chains and assets in the
+// registry
+func (h *Handler) RegistryStats(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	stats := types.RegistryStats{
+		ChainsByNetworkType:  make(map[string]int),
+		ChainsByStatus:       make(map[string]int),
+		AssetsByChain:        make(map[string]int, len(h.assetList)),
+		FilesBySchemaVersion: make(map[string]int),
+		UnknownFields:        h.schemas.unknown,
+	}
+	for _, chain := range h.chainList {
+		networkType, status := unknown, unknown
+		if chain.NetworkType != nil {
+			networkType = string(*chain.NetworkType)
+		}
+		if chain.Status != nil {
+			status = string(*chain.Status)
+		}
+		stats.ChainsByNetworkType[networkType]++
+		stats.ChainsByStatus[status]++
+	}
+	for name, assetList := range h.assetList {
+		stats.AssetsByChain[name] = len(assetList.Assets)
+	}
+	for _, version := range h.schemas.versions {
+		stats.FilesBySchemaVersion[version]++
+	}
+	h.respond(res, req, stats)
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// SetMaxStaleness sets how old the registry may get, when pulls keep failing,
+// before the server reports itself as not ready. Zero means the registry
+// never goes stale.
+func (h *Handler) SetMaxStaleness(maxStaleness time.Duration) {
+	h.maxStaleness = maxStaleness
+}
+
+// Status returns how up to date the registry is, the outcome of the last
+// pull and when the next pull is scheduled
+func (h *Handler) Status(res http.ResponseWriter, req *http.Request) {
+	// the next pull is looked up before taking the lock as the scheduler
+	// reads the registry while holding its own lock
+	h.mtx.RLock()
+	nextPull := h.nextPull
+	h.mtx.RUnlock()
+	var next time.Time
+	if nextPull != nil {
+		next = nextPull()
+	}
+
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	status := h.status(time.Now())
+	if !next.IsZero() {
+		status.NextPull = &next
+	}
+	respondWithJSON(res, status)
+}
+
+// Readyz responds with 503 Service Unavailable once the registry is stale
+func (h *Handler) Readyz(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	if h.awaitingPull || h.status(time.Now()).Stale {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	res.WriteHeader(http.StatusOK)
+}
+
+// status requires the read lock to be held
+func (h *Handler) status(now time.Time) types.ServerStatus {
+	status := types.ServerStatus{
+		Registry:    h.registryUrl,
+		Branch:      h.branch,
+		LastUpdated: h.lastUpdated,
+		Age:         int64(now.Sub(h.lastUpdated).Seconds()),
+		LastAttempt: h.lastAttempt,
+		Pinned:      h.pinned,
+		Stale:       !h.pinned && h.maxStaleness > 0 && now.Sub(h.lastUpdated) > h.maxStaleness,
+	}
+	status.AwaitingPull = h.awaitingPull
+	if h.lastErr != nil {
+		status.LastError = h.lastErr.Error()
+	}
+	status.LastPullDuration = h.lastPullDuration.Seconds()
+	if h.maintenance.Enabled {
+		maintenance := h.maintenance
+		status.Maintenance = &maintenance
+	}
+	if len(h.chainErrors) > 0 {
+		status.ChainErrors = h.chainErrors
+	}
+	if h.source == nil {
+		status.RateLimit = h.quota.rateLimit()
+		fetches := h.fetches.snapshot()
+		status.Fetches = &fetches
+	}
+
+	return status
+}
+
+// RegistryAge is middleware that tells clients how old the served registry is
+// through the X-Registry-Updated and X-Registry-Age headers
+func (h *Handler) RegistryAge(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		h.mtx.RLock()
+		lastUpdated := h.lastUpdated
+		h.mtx.RUnlock()
+
+		res.Header().Set("X-Registry-Updated", lastUpdated.UTC().Format(time.RFC3339))
+		res.Header().Set("X-Registry-Age", strconv.FormatInt(int64(time.Since(lastUpdated).Seconds()), 10))
+		next.ServeHTTP(res, req)
+	})
+}
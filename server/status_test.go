@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// TestStaleRegistry checks that the last good registry keeps being served
+// when the upstream registry is unreachable, and that the server reports
+// itself as not ready once it is older than the max staleness
+func TestStaleRegistry(t *testing.T) {
+	rs := newTestRegistry()
+	h := newTestHandler(rs.URL)
+	h.SetMaxStaleness(time.Hour)
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	rs.Close()
+	if err := h.Pull(context.Background()); err == nil {
+		t.Fatal("pull from an unreachable registry succeeded")
+	}
+
+	router := mux.NewRouter()
+	useRegistryMiddleware(router, h)
+	router.HandleFunc("/v1/chain/{chain}", h.Chain).Methods("GET")
+	router.HandleFunc("/status", h.Status).Methods("GET")
+	router.HandleFunc("/readyz", h.Readyz).Methods("GET")
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/v1/chain/osmosis")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("chain returned %d after a failed pull, want 200", rec.Code)
+	}
+	if rec.Header().Get("X-Registry-Updated") == "" || rec.Header().Get("X-Registry-Age") == "" {
+		t.Errorf("no registry age headers: %v", rec.Header())
+	}
+	var status types.ServerStatus
+	if err := json.Unmarshal(get("/status").Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.LastError == "" || status.Stale {
+		t.Errorf("status %+v, want the last error and a fresh registry", status)
+	}
+	if code := get("/readyz").Code; code != http.StatusOK {
+		t.Errorf("readyz returned %d before the max staleness, want 200", code)
+	}
+
+	h.mtx.Lock()
+	h.lastUpdated = time.Now().Add(-2 * time.Hour)
+	h.mtx.Unlock()
+	if code := get("/readyz").Code; code != http.StatusServiceUnavailable {
+		t.Errorf("readyz returned %d after the max staleness, want 503", code)
+	}
+	if err := json.Unmarshal(get("/status").Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if !status.Stale || status.Age < 2*60*60 {
+		t.Errorf("status %+v, want a stale registry", status)
+	}
+	if code := get("/v1/chain/osmosis").Code; code != http.StatusOK {
+		t.Errorf("chain returned %d once stale, want 200", code)
+	}
+}
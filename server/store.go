@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists the registry so that a server can restart without pulling
+// the entire registry from github again
+type Store interface {
+	// Save replaces the stored registry with the snapshot
+	Save(ctx context.Context, snapshot Snapshot) error
+	// Load returns the stored registry. The snapshot has a zero timestamp if
+	// nothing has been saved yet.
+	Load(ctx context.Context) (Snapshot, error)
+}
+
+// MemoryStore is a Store that keeps the registry in memory. It doesn't
+// survive the process, but lets an embedded Server that is stopped and
+// started again resume without pulling the entire registry.
+type MemoryStore struct {
+	mtx      sync.RWMutex
+	snapshot Snapshot
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func (s *MemoryStore) Save(_ context.Context, snapshot Snapshot) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.snapshot = snapshot
+	return nil
+}
+
+func (s *MemoryStore) Load(_ context.Context) (Snapshot, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.snapshot, nil
+}
+
+// FileStore is a Store that keeps the registry in a file on disk in the
+// format of /admin/export
+type FileStore struct {
+	Path string
+}
+
+var _ Store = FileStore{}
+
+// Save writes the snapshot to a temporary file which then replaces the
+// stored registry, so that a crash never leaves a partially written file
+func (s FileStore) Save(_ context.Context, snapshot Snapshot) error {
+	bz, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+func (s FileStore) Load(_ context.Context) (Snapshot, error) {
+	var snapshot Snapshot
+	bz, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return snapshot, nil
+	}
+	if err != nil {
+		return snapshot, err
+	}
+	err = json.Unmarshal(bz, &snapshot)
+	return snapshot, err
+}
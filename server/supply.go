@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// supplyTTL is how long the supply of an asset is cached
+	supplyTTL = time.Minute
+	// supplyTimeout bounds each query to a REST endpoint
+	supplyTimeout = 5 * time.Second
+)
+
+// supplyCache holds recently queried supplies keyed by chain and denom
+type supplyCache struct {
+	mtx     sync.Mutex
+	entries map[string]types.AssetSupply
+	client  *http.Client
+}
+
+func newSupplyCache(client *http.Client) *supplyCache {
+	return &supplyCache{entries: make(map[string]types.AssetSupply), client: client}
+}
+
+// get returns the supply of the denom on the chain, querying the chain's REST
+// endpoints in order until one of them answers if it isn't cached
+func (c *supplyCache) get(ctx context.Context, chain, denom string, endpoints []types.GrpcElement) (types.AssetSupply, error) {
+	key := chain + "/" + denom
+	now := time.Now()
+	c.mtx.Lock()
+	supply, ok := c.entries[key]
+	c.mtx.Unlock()
+	if ok && now.Sub(supply.Updated) < supplyTTL {
+		return supply, nil
+	}
+
+	if len(endpoints) == 0 {
+		return supply, fmt.Errorf("chain %s has no REST endpoints", chain)
+	}
+	var errs []string
+	for _, endpoint := range endpoints {
+		amount, err := querySupply(ctx, c.client, endpoint.Address, denom)
+		if err != nil {
+			if ctx.Err() != nil {
+				return supply, ctx.Err()
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", endpoint.Address, err))
+			continue
+		}
+		supply = types.AssetSupply{Chain: chain, Denom: denom, Amount: amount, Endpoint: endpoint.Address, Updated: now}
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+		// drop expired entries so that the cache doesn't grow unbounded
+		for k, s := range c.entries {
+			if now.Sub(s.Updated) >= supplyTTL {
+				delete(c.entries, k)
+			}
+		}
+		c.entries[key] = supply
+		return supply, nil
+	}
+	return supply, errors.New(strings.Join(errs, "; "))
+}
+
+// querySupply queries the total supply of a denom from the bank module. The
+// by_denom query was added in Cosmos SDK v0.46, so chains on older versions
+// are queried with the denom in the path instead.
+func querySupply(ctx context.Context, client *http.Client, rest, denom string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, supplyTimeout)
+	defer cancel()
+
+	base := strings.TrimSuffix(rest, "/") + "/cosmos/bank/v1beta1/supply"
+	amount, found, err := getSupply(ctx, client, base+"/by_denom?denom="+url.QueryEscape(denom))
+	if err == nil && !found {
+		amount, found, err = getSupply(ctx, client, base+"/"+denom)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", errors.New("supply query not supported")
+	}
+	return amount, nil
+}
+
+func getSupply(ctx context.Context, client *http.Client, query string) (string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, query, nil)
+	if err != nil {
+		return "", false, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+
+	// unknown routes are reported as 404 or 501 depending on the version
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusNotImplemented {
+		return "", false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, err
+	}
+	var result struct {
+		Amount struct {
+			Amount string `json:"amount"`
+		} `json:"amount"`
+	}
+	if err := json.Unmarshal(bz, &result); err != nil {
+		return "", false, err
+	}
+	if result.Amount.Amount == "" {
+		return "", false, errors.New("no amount in response")
+	}
+	return result.Amount.Amount, true, nil
+}
+
+// AssetSupply returns the total supply of an asset from its chain
+func (h *Handler) AssetSupply(res http.ResponseWriter, req *http.Request) {
+	// the lock is released before the chain is queried
+	h.mtx.RLock()
+	assetName := mux.Vars(req)["asset"]
+	asset, exists := h.findAsset(assetName)
+	chainName := h.chainByAsset[asset.Display]
+	var endpoints []types.GrpcElement
+	if chain := h.chainList[chainName]; chain.Apis != nil {
+		endpoints = chain.Apis.REST
+	}
+	h.mtx.RUnlock()
+
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	supply, err := h.supplies.get(req.Context(), chainName, asset.Base, endpoints)
+	if err != nil {
+		h.log.Printf("querying supply of %s: %v", assetName, err)
+		badGateway(res)
+		return
+	}
+	respondWithJSON(res, supply)
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultRequestTimeout is how long a request may take before it is answered
+// with 503 Service Unavailable
+const DefaultRequestTimeout = 30 * time.Second
+
+// Timeout is middleware that answers requests that take longer than timeout
+// with 503 Service Unavailable and cancels their context, so that handlers
+// querying upstream services can't hold connections indefinitely. Streaming
+// routes, such as genesis files, are exempt as they can be too large to buffer
+// or download in time.
+func Timeout(timeout time.Duration) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		limited := http.TimeoutHandler(next, timeout, "request timed out")
+		return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			if streaming(req) {
+				next.ServeHTTP(res, req)
+				return
+			}
+			limited.ServeHTTP(res, req)
+		})
+	}
+}
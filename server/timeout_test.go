@@ -0,0 +1,43 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// TestStreamingRoutes checks that the responses of streaming routes reach
+// the connection directly rather than through the buffers of the Timeout and
+// signing middleware
+func TestStreamingRoutes(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := mux.NewRouter()
+	router.Use(Timeout(time.Minute))
+	router.Use(NewSigner(key).Middleware)
+	flushes := func(res http.ResponseWriter, req *http.Request) {
+		if _, ok := res.(http.Flusher); ok {
+			res.Header().Set("X-Flushes", "true")
+		}
+		_, _ = res.Write([]byte("{}"))
+	}
+	router.HandleFunc("/genesis", flushes).Name(streamingRoute + "genesis")
+	router.HandleFunc("/chain", flushes)
+
+	for path, streamed := range map[string]bool{"/genesis": true, "/chain": false} {
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		if got := res.Header().Get("X-Flushes") == "true"; got != streamed {
+			t.Errorf("%s streamed %v, want %v", path, got, streamed)
+		}
+		if signed := res.Header().Get(signatureHeader) != ""; signed == streamed {
+			t.Errorf("%s signed %v, want %v", path, signed, !streamed)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// certFiles serves the certificate in a pair of PEM files, reloading it when
+// the certificate file changes so that renewals, e.g. by certbot, are picked
+// up without a restart
+type certFiles struct {
+	certFile, keyFile string
+
+	mtx     sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertFiles(certFile, keyFile string) (*certFiles, error) {
+	c := &certFiles{certFile: certFile, keyFile: keyFile}
+	if _, err := c.GetCertificate(nil); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetCertificate returns the certificate for TLS handshakes. If the files
+// can't be reloaded the previous certificate continues to be served.
+func (c *certFiles) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	info, err := os.Stat(c.certFile)
+	if err != nil {
+		if c.cert != nil {
+			return c.cert, nil
+		}
+		return nil, err
+	}
+	if c.cert == nil || !info.ModTime().Equal(c.modTime) {
+		cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+		if err != nil {
+			if c.cert != nil {
+				return c.cert, nil
+			}
+			return nil, err
+		}
+		c.cert, c.modTime = &cert, info.ModTime()
+	}
+	return c.cert, nil
+}
+
+// loadCertPool reads the PEM encoded certificates in the file into a pool
+func loadCertPool(file string) (*x509.CertPool, error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bz) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
+
+// requestAdminCerts asks clients for a certificate, verifying it against the
+// admin CAs if given. Clients without one can still use the routes other than
+// the admin routes.
+func (o options) requestAdminCerts(config *tls.Config) {
+	if o.adminCAs == nil {
+		return
+	}
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+	config.ClientCAs = o.adminCAs
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// recordTombstones records the chains of the previous registry that are no
+// longer in the upstream registry, and forgets those that are back. Chains
+// that were only filtered out aren't recorded. The caller must hold the write
+// lock.
+func (h *Handler) recordTombstones(chains map[string]types.Chain, assetLists map[string]types.AssetList, upstream map[string]bool, now time.Time) {
+	for name := range upstream {
+		delete(h.tombstones, name)
+	}
+	removed := func(name string) {
+		if upstream[name] {
+			return
+		}
+		if _, ok := h.tombstones[name]; ok {
+			return
+		}
+		h.tombstones[name] = types.Tombstone{Chain: name, ChainID: chains[name].ChainID, Removed: now}
+	}
+	for name := range chains {
+		removed(name)
+	}
+	for name := range assetLists {
+		removed(name)
+	}
+}
+
+// tombstone returns the tombstone of a removed chain given its name or chain
+// id, ignoring case, along with its successor if it is known. The caller must
+// hold the read lock.
+func (h *Handler) tombstone(name string) (types.Tombstone, bool) {
+	for _, tombstone := range h.tombstones {
+		if !strings.EqualFold(tombstone.Chain, name) && !strings.EqualFold(tombstone.ChainID, name) {
+			continue
+		}
+		// a chain that took over the chain id, or a later revision of it,
+		// succeeds the removed chain
+		if tombstone.ChainID != "" {
+			if successor, ok := h.resolveChain(tombstone.ChainID); ok {
+				tombstone.Successor = successor
+			}
+		}
+		return tombstone, true
+	}
+	return types.Tombstone{}, false
+}
+
+// Gone answers requests for a chain that was removed from the registry with
+// 410 Gone and its tombstone, rather than 404 Not Found
+func (h *Handler) Gone(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if name, ok := mux.Vars(req)["chain"]; ok {
+			h.mtx.RLock()
+			_, exists := h.resolveChain(name)
+			tombstone, removed := h.tombstone(name)
+			h.mtx.RUnlock()
+			if !exists && removed {
+				gone(res, tombstone)
+				return
+			}
+		}
+		next.ServeHTTP(res, req)
+	})
+}
+
+// Tombstones returns the chains that were removed from the registry, most
+// recently removed first
+func (h *Handler) Tombstones(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	tombstones := make([]types.Tombstone, 0, len(h.tombstones))
+	for name := range h.tombstones {
+		tombstone, _ := h.tombstone(name)
+		tombstones = append(tombstones, tombstone)
+	}
+	sort.Slice(tombstones, func(i, j int) bool {
+		if !tombstones[i].Removed.Equal(tombstones[j].Removed) {
+			return tombstones[i].Removed.After(tombstones[j].Removed)
+		}
+		return tombstones[i].Chain < tombstones[j].Chain
+	})
+	respondWithJSON(res, tombstones)
+}
+
+func gone(w http.ResponseWriter, tombstone types.Tombstone) {
+	response, _ := json.Marshal(tombstone)
+
+	setCORSHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusGone)
+	_, _ = w.Write(response)
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+func TestTombstones(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	h := newTestHandler(rs.URL)
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// cosmoshub is removed and osmosis is renamed, keeping its chain id
+	rs.RemoveChain("cosmoshub")
+	rs.RemoveChain("osmosis")
+	rs.SetChain("osmosis2", types.Chain{ChainName: "osmosis2", ChainID: "osmosis-1", Bech32Prefix: "osmo"})
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	useRegistryMiddleware(router, h)
+	router.HandleFunc("/v1/chain/{chain}", h.Chain).Methods("GET")
+	router.HandleFunc("/v1/tombstones", h.Tombstones).Methods("GET")
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/v1/chain/cosmoshub")
+	if rec.Code != http.StatusGone {
+		t.Fatalf("removed chain returned %d, want 410", rec.Code)
+	}
+	var tombstone types.Tombstone
+	if err := json.Unmarshal(rec.Body.Bytes(), &tombstone); err != nil {
+		t.Fatal(err)
+	}
+	if tombstone.Chain != "cosmoshub" || tombstone.ChainID != "cosmoshub-4" || tombstone.Removed.IsZero() || tombstone.Successor != "" {
+		t.Errorf("tombstone %+v", tombstone)
+	}
+	// the renamed chain is succeeded by the chain that took over its id,
+	// which is served in its place when queried by that id
+	rec = get("/v1/chain/osmosis")
+	if err := json.Unmarshal(rec.Body.Bytes(), &tombstone); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusGone || tombstone.Successor != "osmosis2" {
+		t.Errorf("renamed chain returned %d with %+v, want 410 with successor osmosis2", rec.Code, tombstone)
+	}
+	if code := get("/v1/chain/osmosis-1").Code; code != http.StatusOK {
+		t.Errorf("chain id of the successor returned %d, want 200", code)
+	}
+	if code := get("/v1/chain/unknown").Code; code != http.StatusNotFound {
+		t.Errorf("chain that never existed returned %d, want 404", code)
+	}
+	var tombstones []types.Tombstone
+	if err := json.Unmarshal(get("/v1/tombstones").Body.Bytes(), &tombstones); err != nil {
+		t.Fatal(err)
+	}
+	if len(tombstones) != 2 {
+		t.Errorf("tombstones %+v, want cosmoshub and osmosis", tombstones)
+	}
+
+	// a chain that comes back loses its tombstone
+	rs.SetChain("cosmoshub", types.Chain{ChainName: "cosmoshub", ChainID: "cosmoshub-4", Bech32Prefix: "cosmos"})
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if code := get("/v1/chain/cosmoshub").Code; code != http.StatusOK {
+		t.Errorf("restored chain returned %d, want 200", code)
+	}
+}
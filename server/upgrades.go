@@ -0,0 +1,63 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// Upgrades returns the versions listed in the codebase of a chain as an
+// upgrade plan for Cosmovisor, so that the binaries of upcoming upgrades can
+// be staged ahead of their height
+func (h *Handler) Upgrades(res http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	vars := mux.Vars(req)
+	chainName, ok := vars["chain"]
+	if !ok {
+		badRequest(res)
+		return
+	}
+
+	exists, chain := h.findChain(chainName)
+	if !exists {
+		resourceNotFound(res)
+		return
+	}
+	h.respond(res, req, upgrades(chain))
+}
+
+func upgrades(chain types.Chain) []types.Upgrade {
+	upgrades := make([]types.Upgrade, 0)
+	if chain.Codebase == nil {
+		return upgrades
+	}
+	for _, version := range chain.Codebase.Versions {
+		upgrade := types.Upgrade{
+			Name:     version.Name,
+			Version:  version.RecommendedVersion,
+			Binaries: version.Binaries,
+			Dir:      "upgrades/" + version.Name,
+		}
+		if upgrade.Version == "" {
+			upgrade.Version = version.Tag
+		}
+		// a version without an upgrade height is the one the chain was
+		// launched with
+		if version.Height != nil && *version.Height > 0 {
+			upgrade.Height = version.Height
+		} else {
+			upgrade.Dir = "genesis"
+		}
+		if len(version.Binaries) > 0 {
+			info, _ := json.Marshal(map[string]interface{}{"binaries": version.Binaries})
+			upgrade.Info = string(info)
+		}
+		upgrades = append(upgrades, upgrade)
+	}
+	return upgrades
+}
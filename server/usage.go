@@ -0,0 +1,120 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// usageCounter counts the requests served by route, status, API key and chain
+type usageCounter struct {
+	mtx       sync.Mutex
+	since     time.Time
+	requests  int64
+	routes    map[string]*types.RouteUsage
+	consumers map[string]int64
+	chains    map[string]int64
+}
+
+func newUsageCounter() *usageCounter {
+	return &usageCounter{
+		since:     time.Now(),
+		routes:    make(map[string]*types.RouteUsage),
+		consumers: make(map[string]int64),
+		chains:    make(map[string]int64),
+	}
+}
+
+func (u *usageCounter) count(route string, status int, consumer, chain string) {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	u.requests++
+	r, ok := u.routes[route]
+	if !ok {
+		r = &types.RouteUsage{Statuses: make(map[int]int64)}
+		u.routes[route] = r
+	}
+	r.Requests++
+	r.Statuses[status]++
+	if consumer != "" {
+		u.consumers[consumer]++
+	}
+	if chain != "" {
+		u.chains[chain]++
+	}
+}
+
+func (u *usageCounter) snapshot() types.Usage {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	usage := types.Usage{
+		Since:     u.since,
+		Requests:  u.requests,
+		Routes:    make(map[string]types.RouteUsage, len(u.routes)),
+		Consumers: make(map[string]int64, len(u.consumers)),
+		Chains:    make(map[string]int64, len(u.chains)),
+	}
+	for route, r := range u.routes {
+		statuses := make(map[int]int64, len(r.Statuses))
+		for status, n := range r.Statuses {
+			statuses[status] = n
+		}
+		usage.Routes[route] = types.RouteUsage{Requests: r.Requests, Statuses: statuses}
+	}
+	for consumer, n := range u.consumers {
+		usage.Consumers[consumer] = n
+	}
+	for chain, n := range u.chains {
+		usage.Chains[chain] = n
+	}
+	return usage
+}
+
+// CountUsage returns a middleware that counts each request by its route and
+// the status of its response, by the API key it carries if keys are
+// configured and, when it succeeds, by the name of the chain it asks for.
+// Unknown keys and chains aren't counted separately so that clients can't grow the
+// counters without bound.
+func (h *Handler) CountUsage(keys APIKeys) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			recorder := &statusRecorder{ResponseWriter: res, status: http.StatusOK}
+			next.ServeHTTP(recorder, req)
+
+			route := req.URL.Path
+			if r := mux.CurrentRoute(req); r != nil {
+				if tpl, err := r.GetPathTemplate(); err == nil {
+					route = tpl
+				}
+			}
+			var consumer string
+			if len(keys) > 0 {
+				consumer = "anonymous"
+				if key := apiKey(req); key != "" {
+					if _, ok := keys.lookup(key); ok {
+						consumer = keyFingerprint(key)
+					}
+				}
+			}
+			// chains are counted by their name in the registry rather than
+			// the name, alias or chain id of the request, which would let
+			// every spelling of a chain add a counter
+			var chain string
+			if name, ok := mux.Vars(req)["chain"]; ok && recorder.status < 400 {
+				h.mtx.RLock()
+				chain, _ = h.resolveChain(name)
+				h.mtx.RUnlock()
+			}
+			h.usage.count(route, recorder.status, consumer, chain)
+		})
+	}
+}
+
+// Usage returns the requests counted since the server started
+func (h *Handler) Usage(res http.ResponseWriter, req *http.Request) {
+	respondWithJSON(res, h.usage.snapshot())
+}
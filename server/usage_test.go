@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// TestUsageChains checks that chains are counted by their name in the registry
+// however the request spells them
+func TestUsageChains(t *testing.T) {
+	rs := newTestRegistry()
+	defer rs.Close()
+	h := newTestHandler(rs.URL)
+	if err := h.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	router := mux.NewRouter()
+	router.Use(h.CountUsage(nil))
+	router.HandleFunc("/v1/chain/{chain}", h.Chain).Methods("GET")
+
+	for _, chain := range []string{"osmosis", "OSMOSIS", "osmosis-1", "osmosis-0", "osmosis-00", "unknown", "cosmoshub"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/chain/"+chain, nil))
+	}
+	want := map[string]int64{"osmosis": 5, "cosmoshub": 1}
+	if got := h.usage.snapshot().Chains; !reflect.DeepEqual(got, want) {
+		t.Errorf("chains counted %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// Version, Commit and BuildDate describe the build. They are set with ldflags
+// when building a release:
+//
+//	go build -ldflags "-X github.com/cmwaters/skychart/server.Version=v1.2.0 \
+//		-X github.com/cmwaters/skychart/server.Commit=$(git rev-parse HEAD) \
+//		-X github.com/cmwaters/skychart/server.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Without them, the version is the module version when installed with go
+// install and dev otherwise.
+var (
+	Version   string
+	Commit    string
+	BuildDate string
+)
+
+// build describes the running build
+func build() types.Build {
+	version := Version
+	if version == "" {
+		version = "dev"
+		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			version = info.Main.Version
+		}
+	}
+	return types.Build{
+		Version:   version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// BuildInfo responds with the version of skychart that is running
+func BuildInfo(res http.ResponseWriter, req *http.Request) {
+	respondWithJSON(res, build())
+}
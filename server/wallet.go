@@ -0,0 +1,127 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+const (
+	// defaultCoinType is the slip44 coin type of the cosmos hub which most
+	// chains reuse when they don't specify their own
+	defaultCoinType = 118
+)
+
+// keplrChainInfo converts a chain and its asset list into the ChainInfo format
+// used by Keplr's experimentalSuggestChain. The first asset in the asset list
+// is treated as the staking currency.
+func keplrChainInfo(chain types.Chain, assetList types.AssetList) types.ChainInfo {
+	info := types.ChainInfo{
+		ChainID:   chain.ChainID,
+		ChainName: chain.ChainName,
+		BIP44:     types.BIP44{CoinType: defaultCoinType},
+		Bech32Config: types.Bech32Config{
+			Bech32PrefixAccAddr:  chain.Bech32Prefix,
+			Bech32PrefixAccPub:   chain.Bech32Prefix + "pub",
+			Bech32PrefixValAddr:  chain.Bech32Prefix + "valoper",
+			Bech32PrefixValPub:   chain.Bech32Prefix + "valoperpub",
+			Bech32PrefixConsAddr: chain.Bech32Prefix + "valcons",
+			Bech32PrefixConsPub:  chain.Bech32Prefix + "valconspub",
+		},
+		Currencies:    make([]types.Currency, 0, len(assetList.Assets)),
+		FeeCurrencies: make([]types.Currency, 0),
+	}
+	if chain.PrettyName != nil {
+		info.ChainName = *chain.PrettyName
+	}
+	if chain.Slip44 != nil {
+		info.BIP44.CoinType = int64(*chain.Slip44)
+	}
+	if chain.Apis != nil {
+		if len(chain.Apis.RPC) > 0 {
+			info.RPC = chain.Apis.RPC[0].Address
+		}
+		if len(chain.Apis.REST) > 0 {
+			info.REST = chain.Apis.REST[0].Address
+		}
+	}
+
+	for _, asset := range assetList.Assets {
+		info.Currencies = append(info.Currencies, keplrCurrency(asset))
+	}
+	if len(info.Currencies) > 0 {
+		stake := info.Currencies[0]
+		info.StakeCurrency = &stake
+	}
+
+	if chain.Fees != nil {
+		for _, token := range chain.Fees.FeeTokens {
+			info.FeeCurrencies = append(info.FeeCurrencies, feeCurrency(token.Denom, info.Currencies))
+		}
+	}
+
+	return info
+}
+
+// cosmostationChain converts a chain and its asset list into the format used by
+// Cosmostation's cos_addChain. Like Keplr, the first asset in the asset list is
+// treated as the chain's native currency.
+func cosmostationChain(chain types.Chain, assetList types.AssetList) types.CosmostationChain {
+	info := keplrChainInfo(chain, assetList)
+	cosmostation := types.CosmostationChain{
+		ChainID:       info.ChainID,
+		ChainName:     info.ChainName,
+		AddressPrefix: chain.Bech32Prefix,
+		RestURL:       info.REST,
+		CoinType:      strconv.FormatInt(info.BIP44.CoinType, 10),
+	}
+	if info.StakeCurrency != nil {
+		cosmostation.BaseDenom = info.StakeCurrency.CoinMinimalDenom
+		cosmostation.DisplayDenom = info.StakeCurrency.CoinDenom
+		cosmostation.Decimals = info.StakeCurrency.CoinDecimals
+		cosmostation.ImageURL = info.StakeCurrency.CoinImageURL
+		cosmostation.CoinGeckoID = info.StakeCurrency.CoinGeckoID
+	}
+	if isEthermint(chain) {
+		cosmostation.Type = "ETHERMINT"
+	}
+	return cosmostation
+}
+
+// keplrCurrency describes an asset as a Keplr currency. The decimals are taken
+// from the exponent of the display denom unit.
+func keplrCurrency(asset types.AssetElement) types.Currency {
+	currency := types.Currency{
+		CoinDenom:        asset.Display,
+		CoinMinimalDenom: asset.Base,
+		CoinGeckoID:      asset.CoingeckoID,
+	}
+	if asset.Symbol != nil {
+		currency.CoinDenom = *asset.Symbol
+	}
+	for _, unit := range asset.DenomUnits {
+		if unit.Denom == asset.Display {
+			currency.CoinDecimals = unit.Exponent
+			break
+		}
+	}
+	if asset.LogoURIs != nil {
+		if asset.LogoURIs.PNG != nil {
+			currency.CoinImageURL = asset.LogoURIs.PNG
+		} else {
+			currency.CoinImageURL = asset.LogoURIs.SVG
+		}
+	}
+	return currency
+}
+
+// feeCurrency returns the currency matching the fee denom. If the denom isn't in
+// the asset list, it's returned as is without any decimals.
+func feeCurrency(denom string, currencies []types.Currency) types.Currency {
+	for _, currency := range currencies {
+		if currency.CoinMinimalDenom == denom {
+			return currency
+		}
+	}
+	return types.Currency{CoinDenom: denom, CoinMinimalDenom: denom}
+}
@@ -0,0 +1,255 @@
+// Package testutil provides helpers for testing code that depends on the
+// chain-registry without touching github.com.
+package testutil
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cmwaters/skychart/types"
+)
+
+// RegistryServer is an httptest server emulating the parts of github's
+// contents, trees, commits, tarball and raw content APIs that are used to pull the registry.
+// It serves an in-memory registry which can be modified while the server is
+// running. Both the api and raw urls of the handler should be set to URL.
+type RegistryServer struct {
+	*httptest.Server
+
+	repo string
+
+	mtx        sync.Mutex
+	chains     map[string]types.Chain
+	assetLists map[string]types.AssetList
+	commits    []time.Time
+}
+
+// NewRegistryServer starts a server hosting an empty registry for the repo,
+// e.g. "cosmos/chain-registry". Close should be called once finished.
+func NewRegistryServer(repo string) *RegistryServer {
+	rs := &RegistryServer{
+		repo:       repo,
+		chains:     make(map[string]types.Chain),
+		assetLists: make(map[string]types.AssetList),
+		commits:    make([]time.Time, 0),
+	}
+	rs.Server = httptest.NewServer(http.HandlerFunc(rs.serve))
+	return rs
+}
+
+// SetChain adds or replaces the chain.json of the chain
+func (rs *RegistryServer) SetChain(name string, chain types.Chain) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	rs.chains[name] = chain
+	rs.commit()
+}
+
+// SetAssetList adds or replaces the assetlist.json of the chain
+func (rs *RegistryServer) SetAssetList(name string, assetList types.AssetList) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	rs.assetLists[name] = assetList
+	rs.commit()
+}
+
+// RemoveChain deletes the chain's directory from the registry
+func (rs *RegistryServer) RemoveChain(name string) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+	delete(rs.chains, name)
+	delete(rs.assetLists, name)
+	rs.commit()
+}
+
+// commit records a change to the registry
+func (rs *RegistryServer) commit() {
+	rs.commits = append(rs.commits, time.Now())
+}
+
+func (rs *RegistryServer) serve(res http.ResponseWriter, req *http.Request) {
+	rs.mtx.Lock()
+	defer rs.mtx.Unlock()
+
+	apiPrefix := "/repos/" + rs.repo
+	rawPrefix := "/" + rs.repo + "/"
+	switch {
+	case req.URL.Path == apiPrefix+"/contents":
+		rs.serveContents(res)
+	case strings.HasPrefix(req.URL.Path, apiPrefix+"/git/trees/"):
+		rs.serveTree(res, req)
+	case req.URL.Path == apiPrefix+"/commits":
+		rs.serveCommits(res, req)
+	case strings.HasPrefix(req.URL.Path, apiPrefix+"/commits/"):
+		rs.serveLatestCommit(res)
+	case strings.HasPrefix(req.URL.Path, apiPrefix+"/tarball/"):
+		rs.serveTarball(res)
+	case strings.HasPrefix(req.URL.Path, rawPrefix):
+		rs.serveRaw(res, strings.TrimPrefix(req.URL.Path, rawPrefix))
+	default:
+		res.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// serveContents lists the chains like the contents API, which truncates the
+// listing at 1000 entries
+func (rs *RegistryServer) serveContents(res http.ResponseWriter) {
+	names := rs.names()
+	if len(names) > 1000 {
+		names = names[:1000]
+	}
+	entries := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, map[string]interface{}{"name": name, "path": name, "type": "dir"})
+	}
+	writeJSON(res, entries)
+}
+
+// serveTree lists the chains like the git trees API, along with their files
+// if ?recursive= is set, regardless of the requested ref
+func (rs *RegistryServer) serveTree(res http.ResponseWriter, req *http.Request) {
+	names := rs.names()
+	recursive := req.URL.Query().Get("recursive") != ""
+	entries := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, map[string]interface{}{"path": name, "type": "tree"})
+		if !recursive {
+			continue
+		}
+		if _, ok := rs.chains[name]; ok {
+			entries = append(entries, map[string]interface{}{"path": name + "/chain.json", "type": "blob"})
+		}
+		if _, ok := rs.assetLists[name]; ok {
+			entries = append(entries, map[string]interface{}{"path": name + "/assetlist.json", "type": "blob"})
+		}
+	}
+	writeJSON(res, map[string]interface{}{"tree": entries, "truncated": false})
+}
+
+// names returns the sorted names of the chains in the registry
+func (rs *RegistryServer) names() []string {
+	set := make(map[string]struct{})
+	for name := range rs.chains {
+		set[name] = struct{}{}
+	}
+	for name := range rs.assetLists {
+		set[name] = struct{}{}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// serveLatestCommit serves the latest commit regardless of the requested ref
+func (rs *RegistryServer) serveLatestCommit(res http.ResponseWriter) {
+	if len(rs.commits) == 0 {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(res, map[string]interface{}{"sha": sha(len(rs.commits) - 1)})
+}
+
+// sha returns a fake but stable SHA for the i-th commit
+func sha(i int) string {
+	return fmt.Sprintf("%040x", i+1)
+}
+
+func (rs *RegistryServer) serveCommits(res http.ResponseWriter, req *http.Request) {
+	since := time.Unix(0, 0)
+	if s := req.URL.Query().Get("since"); s != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			res.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+	}
+	commits := make([]map[string]interface{}, 0)
+	for i, commit := range rs.commits {
+		if commit.After(since) {
+			commits = append(commits, map[string]interface{}{
+				"sha": sha(i),
+				"commit": map[string]interface{}{
+					"committer": map[string]interface{}{"date": commit.Format(time.RFC3339)},
+				},
+			})
+		}
+	}
+	writeJSON(res, commits)
+}
+
+// serveRaw serves a file of the form {ref}/{chain}/{file}, where the ref may
+// contain slashes, e.g. refs/pull/1/head. All refs share the same registry.
+func (rs *RegistryServer) serveRaw(res http.ResponseWriter, file string) {
+	parts := strings.Split(file, "/")
+	if len(parts) < 3 {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+	chainName, fileName := parts[len(parts)-2], parts[len(parts)-1]
+	switch fileName {
+	case "chain.json":
+		if chain, ok := rs.chains[chainName]; ok {
+			writeJSON(res, chain)
+			return
+		}
+	case "assetlist.json":
+		if assetList, ok := rs.assetLists[chainName]; ok {
+			writeJSON(res, assetList)
+			return
+		}
+	}
+	res.WriteHeader(http.StatusNotFound)
+}
+
+// serveTarball serves the registry as a gzipped tarball nested in a directory
+// named after the repo and latest commit, as github does
+func (rs *RegistryServer) serveTarball(res http.ResponseWriter) {
+	root := strings.ReplaceAll(rs.repo, "/", "-") + "-" + sha(len(rs.commits) - 1)[:7] + "/"
+	files := make(map[string]interface{})
+	for name, chain := range rs.chains {
+		files[name+"/chain.json"] = chain
+	}
+	for name, assetList := range rs.assetLists {
+		files[name+"/assetlist.json"] = assetList
+	}
+
+	res.Header().Set("Content-Type", "application/x-gzip")
+	gz := gzip.NewWriter(res)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: root, Typeflag: tar.TypeDir, Mode: 0o755})
+	dirs := make(map[string]bool)
+	for file, payload := range files {
+		dir := strings.Split(file, "/")[0]
+		if !dirs[dir] {
+			dirs[dir] = true
+			_ = tw.WriteHeader(&tar.Header{Name: root + dir + "/", Typeflag: tar.TypeDir, Mode: 0o755})
+		}
+		bz, _ := json.Marshal(payload)
+		_ = tw.WriteHeader(&tar.Header{Name: root + file, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(bz))})
+		_, _ = tw.Write(bz)
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+}
+
+func writeJSON(res http.ResponseWriter, payload interface{}) {
+	bz, err := json.Marshal(payload)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	_, _ = res.Write(bz)
+}
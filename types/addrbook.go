@@ -0,0 +1,41 @@
+package types
+
+import "time"
+
+// AddrBook is the addrbook.json of a Tendermint node
+type AddrBook struct {
+	Key   string         `json:"key"`
+	Addrs []KnownAddress `json:"addrs"`
+}
+
+// KnownAddress is a peer in a Tendermint address book
+type KnownAddress struct {
+	Addr        NetAddress `json:"addr"`
+	Src         NetAddress `json:"src"`
+	Buckets     []int      `json:"buckets"`
+	Attempts    int32      `json:"attempts"`
+	BucketType  byte       `json:"bucket_type"`
+	LastAttempt time.Time  `json:"last_attempt"`
+	LastSuccess time.Time  `json:"last_success"`
+	LastBanTime time.Time  `json:"last_ban_time"`
+}
+
+// NetAddress is the address of a Tendermint peer
+type NetAddress struct {
+	ID   string `json:"id"`
+	IP   string `json:"ip"`
+	Port uint16 `json:"port"`
+}
+
+// NodeConfig is the p2p section of a Tendermint node's config.toml. Peers are
+// comma separated id@host:port lists.
+type NodeConfig struct {
+	ExternalAddress string `json:"external_address"`
+	Seeds           string `json:"seeds"`
+	PersistentPeers string `json:"persistent_peers"`
+}
+
+// AppConfig holds the settings of a node's app.toml suggested by the registry
+type AppConfig struct {
+	MinimumGasPrices string `json:"minimum_gas_prices"`
+}
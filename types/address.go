@@ -0,0 +1,8 @@
+package types
+
+// AddressConversion is an address re-encoded with the bech32 prefix of a chain
+type AddressConversion struct {
+	Address string `json:"address"`
+	Chain   string `json:"chain"`
+	Prefix  string `json:"prefix"`
+}
@@ -0,0 +1,8 @@
+package types
+
+// Aliases lists the alternative names that chains and assets can be looked up
+// by, keyed by the chain name or asset display name
+type Aliases struct {
+	Chains map[string][]string `json:"chains"`
+	Assets map[string][]string `json:"assets"`
+}
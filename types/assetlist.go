@@ -19,6 +19,9 @@ type AssetElement struct {
 	LogoURIs    *LogoURIs          `json:"logo_URIs,omitempty"`
 	Name        *string            `json:"name,omitempty"`   // The project name of the asset. For example Bitcoin.
 	Symbol      *string            `json:"symbol,omitempty"` // The symbol of an asset. For example BTC.
+	// Traces are the hops the asset took from the asset it came from, as
+	// recorded by registries in the newer schema, oldest first
+	Traces []Trace `json:"traces,omitempty"`
 }
 
 type DenomUnitElement struct {
@@ -31,6 +34,30 @@ type Ibc struct {
 	DstChannel    string `json:"dst_channel"`
 	SourceChannel string `json:"source_channel"`
 	SourceDenom   string `json:"source_denom"`
+	// SourceChain is the name of the chain the asset was transferred from.
+	// It is only known for registries that trace assets.
+	SourceChain string `json:"source_chain,omitempty"`
+}
+
+// Trace is a hop of an asset, such as an IBC transfer or a bridge, from the
+// asset on the counterparty chain
+type Trace struct {
+	Type         string            `json:"type"`
+	Counterparty TraceCounterparty `json:"counterparty"`
+	Chain        *TraceChain       `json:"chain,omitempty"`
+	Provider     string            `json:"provider,omitempty"`
+}
+
+type TraceCounterparty struct {
+	ChainName string `json:"chain_name"`
+	BaseDenom string `json:"base_denom"`
+	ChannelID string `json:"channel_id,omitempty"`
+}
+
+// TraceChain is the side of an IBC trace on the chain the asset is listed on
+type TraceChain struct {
+	ChannelID string `json:"channel_id"`
+	Path      string `json:"path,omitempty"`
 }
 
 type LogoURIs struct {
@@ -44,6 +71,21 @@ type Kind string
 const (
 	Cw20    Kind = "cw20"
 	Erc20   Kind = "erc20"
+	Ics20   Kind = "ics20"
 	SDKCoin Kind = "sdk.coin"
 	Snip20  Kind = "snip20"
 )
+
+// ChainAssetElement is an asset along with the chain it is registered on
+type ChainAssetElement struct {
+	Chain   string `json:"chain"`
+	ChainID string `json:"chain_id"`
+	AssetElement
+}
+
+// BridgedAsset is an asset along with the variants of it that were transferred
+// to other chains over IBC, returned when ?include=bridged is passed
+type BridgedAsset struct {
+	PricedAsset
+	Bridged []ChainAssetElement `json:"bridged"`
+}
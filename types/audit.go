@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// AuditEntry records a request to an admin route that changes the state of
+// the server
+type AuditEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Principal identifies who made the request: the basic auth username, a
+	// fingerprint of the API key or the subject of the client certificate
+	Principal string `json:"principal"`
+	// Action is the method and path of the request, e.g. POST /admin/import
+	Action string `json:"action"`
+	// Status is the status code of the response. Requests refused by the
+	// authentication are recorded too.
+	Status  int    `json:"status"`
+	Outcome string `json:"outcome"`
+}
@@ -0,0 +1,20 @@
+package types
+
+// BankMetadata is the metadata of a denom held by the Cosmos SDK's x/bank
+// module, in the JSON shape of its Metadata message as found in genesis files
+type BankMetadata struct {
+	Description string          `json:"description"`
+	DenomUnits  []BankDenomUnit `json:"denom_units"`
+	Base        string          `json:"base"`
+	Display     string          `json:"display"`
+	Name        string          `json:"name"`
+	Symbol      string          `json:"symbol"`
+	URI         string          `json:"uri"`
+	URIHash     string          `json:"uri_hash"`
+}
+
+type BankDenomUnit struct {
+	Denom    string   `json:"denom"`
+	Exponent int64    `json:"exponent"`
+	Aliases  []string `json:"aliases"`
+}
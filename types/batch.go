@@ -0,0 +1,19 @@
+package types
+
+import "encoding/json"
+
+// ChainBatch holds the chains of a batch request keyed by the name or ID they
+// were requested by, and the names that didn't match any chain
+type ChainBatch struct {
+	Chains   map[string]Chain `json:"chains"`
+	NotFound []string         `json:"not_found"`
+}
+
+// BatchResult is the response to one of the queries of a batch
+type BatchResult struct {
+	Query  string `json:"query"`
+	Status int    `json:"status"`
+	// Body is the JSON of the response, or a string holding the response if
+	// it isn't JSON. It is left out if the response is empty.
+	Body json.RawMessage `json:"body,omitempty"`
+}
@@ -0,0 +1,10 @@
+package types
+
+// Build identifies the build of skychart that is running
+type Build struct {
+	Version string `json:"version"`
+	// Commit and BuildDate are only known if they were set when building
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"build_date,omitempty"`
+	GoVersion string `json:"go_version"`
+}
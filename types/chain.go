@@ -13,11 +13,13 @@ type Chain struct {
 	Fees         *Fees             `json:"fees,omitempty"`
 	Genesis      *Genesis          `json:"genesis,omitempty"`
 	KeyAlgos     []KeyAlgo         `json:"key_algos,omitempty"`
+	LogoURIs     *LogoURIs         `json:"logo_URIs,omitempty"`
 	NetworkType  *NetworkType      `json:"network_type,omitempty"`
 	NodeHome     *string           `json:"node_home,omitempty"`
 	Peers        *Peers            `json:"peers,omitempty"`
 	PrettyName   *string           `json:"pretty_name,omitempty"`
 	Slip44       *float64          `json:"slip44,omitempty"`
+	Staking      *Staking          `json:"staking,omitempty"`
 	Status       *Status           `json:"status,omitempty"`
 }
 
@@ -33,10 +35,23 @@ type GrpcElement struct {
 }
 
 type Codebase struct {
-	Binaries           *Binaries `json:"binaries,omitempty"`
-	CompatibleVersions []string  `json:"compatible_versions"`
-	GitRepo            string    `json:"git_repo"`
-	RecommendedVersion string    `json:"recommended_version"`
+	Binaries           *Binaries         `json:"binaries,omitempty"`
+	CompatibleVersions []string          `json:"compatible_versions"`
+	GitRepo            string            `json:"git_repo"`
+	RecommendedVersion string            `json:"recommended_version"`
+	Versions           []CodebaseVersion `json:"versions,omitempty"`
+}
+
+// CodebaseVersion is a version of the chain's software, starting from the
+// version the chain was launched with. Height is the block height the version
+// took effect at.
+type CodebaseVersion struct {
+	Binaries           map[string]string `json:"binaries,omitempty"`
+	Height             *float64          `json:"height,omitempty"`
+	Name               string            `json:"name"`
+	NextVersionName    string            `json:"next_version_name,omitempty"`
+	RecommendedVersion string            `json:"recommended_version,omitempty"`
+	Tag                string            `json:"tag,omitempty"`
 }
 
 type Binaries struct {
@@ -44,9 +59,10 @@ type Binaries struct {
 }
 
 type ExplorerElement struct {
-	Kind   *string `json:"kind,omitempty"`
-	TxPage *string `json:"tx_page,omitempty"`
-	URL    *string `json:"url,omitempty"`
+	AccountPage *string `json:"account_page,omitempty"`
+	Kind        *string `json:"kind,omitempty"`
+	TxPage      *string `json:"tx_page,omitempty"`
+	URL         *string `json:"url,omitempty"`
 }
 
 type Fees struct {
@@ -56,6 +72,25 @@ type Fees struct {
 type FeeTokenElement struct {
 	Denom            string   `json:"denom"`
 	FixedMinGasPrice *float64 `json:"fixed_min_gas_price,omitempty"`
+	LowGasPrice      *float64 `json:"low_gas_price,omitempty"`
+	AverageGasPrice  *float64 `json:"average_gas_price,omitempty"`
+	HighGasPrice     *float64 `json:"high_gas_price,omitempty"`
+}
+
+type Staking struct {
+	LockDuration  *LockDuration         `json:"lock_duration,omitempty"`
+	StakingTokens []StakingTokenElement `json:"staking_tokens"`
+}
+
+// LockDuration is how long tokens stay locked after unbonding, in blocks or
+// as a duration such as "1209600s"
+type LockDuration struct {
+	Blocks *float64 `json:"blocks,omitempty"`
+	Time   *string  `json:"time,omitempty"`
+}
+
+type StakingTokenElement struct {
+	Denom string `json:"denom"`
 }
 
 type Genesis struct {
@@ -0,0 +1,8 @@
+package types
+
+// ChainIDs lists the current chain id of a chain and the ids it was
+// previously known by, which still resolve to it
+type ChainIDs struct {
+	ChainID  string   `json:"chain_id"`
+	Previous []string `json:"previous"`
+}
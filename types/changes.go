@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// Changes summarises how the registry has changed since a point in time
+type Changes struct {
+	Since time.Time `json:"since"`
+	// Complete is false if the server's change history doesn't reach back to
+	// Since, in which case older changes are missing
+	Complete bool      `json:"complete"`
+	Chains   ChangeSet `json:"chains"`
+	Assets   ChangeSet `json:"assets"`
+}
+
+// ChangeSet lists the names of the records that were added, removed or modified
+type ChangeSet struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
+}
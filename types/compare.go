@@ -0,0 +1,32 @@
+package types
+
+// Comparison is the difference between two states of the registry
+type Comparison struct {
+	Base   RegistryState `json:"base"`
+	Head   RegistryState `json:"head"`
+	Chains ChangeSet     `json:"chains"`
+	// Assets are identified as {chain}/{base denom}
+	Assets ChangeSet `json:"assets"`
+	// Endpoints lists the endpoints added to or removed from the chains in
+	// both states, keyed by the chain name and then the kind of endpoint:
+	// rpc, rest, grpc, peers or seeds
+	Endpoints map[string]map[string]EndpointChanges `json:"endpoints"`
+}
+
+// RegistryState identifies one of the states of a Comparison
+type RegistryState struct {
+	// Ref is the commit, git ref or timestamp as requested, or empty for the
+	// registry currently served
+	Ref    string `json:"ref"`
+	Commit string `json:"commit,omitempty"`
+	// Complete is false if the state is from before the server's history
+	// of the registry, in which case older versions are missing
+	Complete bool `json:"complete"`
+}
+
+// EndpointChanges lists the addresses of the endpoints that were added or
+// removed. Peers and seeds are addressed as id@host:port.
+type EndpointChanges struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
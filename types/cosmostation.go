@@ -0,0 +1,17 @@
+package types
+
+// CosmostationChain is the chain configuration accepted by Cosmostation's
+// cos_addChain request. It is derived from a chain's chain.json and assetlist.json.
+type CosmostationChain struct {
+	ChainID       string  `json:"chainId"`
+	ChainName     string  `json:"chainName"`
+	AddressPrefix string  `json:"addressPrefix"`
+	BaseDenom     string  `json:"baseDenom"`
+	DisplayDenom  string  `json:"displayDenom"`
+	RestURL       string  `json:"restURL"`
+	CoinType      string  `json:"coinType,omitempty"`
+	Decimals      int64   `json:"decimals,omitempty"`
+	Type          string  `json:"type,omitempty"`
+	ImageURL      *string `json:"imageURL,omitempty"`
+	CoinGeckoID   *string `json:"coinGeckoId,omitempty"`
+}
@@ -0,0 +1,7 @@
+package types
+
+// ExplorerLink is a link to a transaction or account on a block explorer
+type ExplorerLink struct {
+	Kind string `json:"kind,omitempty"`
+	URL  string `json:"url"`
+}
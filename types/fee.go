@@ -0,0 +1,20 @@
+package types
+
+// FeeToken is a fee token of a chain along with, for IBC denominations, the
+// asset it was transferred from
+type FeeToken struct {
+	FeeTokenElement
+	// Origin is set for ibc/ denoms that the chain's asset list registers
+	Origin *FeeTokenOrigin `json:"origin,omitempty"`
+}
+
+// FeeTokenOrigin is the asset an IBC denomination was transferred from
+type FeeTokenOrigin struct {
+	Symbol  string `json:"symbol,omitempty"`
+	Display string `json:"display"`
+	// Chain is the name of the chain the asset was transferred from, if known
+	Chain string `json:"chain,omitempty"`
+	// Denom is the base denom of the asset on the chain it was transferred
+	// from
+	Denom string `json:"denom"`
+}
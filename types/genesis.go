@@ -0,0 +1,12 @@
+package types
+
+// GenesisInfo describes how to bootstrap a node of a chain from genesis
+type GenesisInfo struct {
+	ChainName  string `json:"chain_name"`
+	ChainID    string `json:"chain_id"`
+	GenesisURL string `json:"genesis_url"`
+	// Sha256 is the checksum published next to the genesis file, if any
+	Sha256 string `json:"sha256,omitempty"`
+	// InitialHeight is the height the chain was launched at, if known
+	InitialHeight *float64 `json:"initial_height,omitempty"`
+}
@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// ChainHistory contains the most recent versions of a chain's chain.json and
+// assetlist.json, oldest first. The last version is the one currently served.
+type ChainHistory struct {
+	Chain     []ChainVersion     `json:"chain"`
+	AssetList []AssetListVersion `json:"asset_list"`
+}
+
+// ChainVersion is a version of a chain.json and the time it was first pulled
+type ChainVersion struct {
+	Timestamp time.Time `json:"timestamp"`
+	Chain     Chain     `json:"chain"`
+}
+
+// AssetListVersion is a version of an assetlist.json and the time it was first pulled
+type AssetListVersion struct {
+	Timestamp time.Time `json:"timestamp"`
+	AssetList AssetList `json:"asset_list"`
+}
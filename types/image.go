@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// Image is a logo listed in the logo_URIs of a chain or asset
+type Image struct {
+	Chain string `json:"chain"`
+	// Asset is the base denom of the asset, or empty for the chain's logo
+	Asset  string `json:"asset,omitempty"`
+	Format string `json:"format"` // png or svg
+	URI    string `json:"uri"`
+	// Check is the outcome of the last check of the link, if images are
+	// checked and it has been yet
+	Check *ImageCheck `json:"check,omitempty"`
+}
+
+// ImageCheck is the outcome of fetching an image
+type ImageCheck struct {
+	Broken  bool      `json:"broken"`
+	Error   string    `json:"error,omitempty"`
+	Checked time.Time `json:"checked"`
+}
@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+// Integrity lets clients verify that the registry files they are served are
+// unmodified
+type Integrity struct {
+	// Commit is the commit of the registry the files were pulled at, if the
+	// source has commits
+	Commit      string    `json:"commit,omitempty"`
+	LastUpdated time.Time `json:"last_updated"`
+	// Files maps the path of each file in the registry, e.g.
+	// osmosis/chain.json, to the hex encoded SHA-256 of its contents as
+	// pulled
+	Files map[string]string `json:"files"`
+}
@@ -0,0 +1,36 @@
+package types
+
+// ChainInfo is the chain configuration accepted by Keplr's experimentalSuggestChain.
+// It is derived from a chain's chain.json and assetlist.json.
+type ChainInfo struct {
+	ChainID       string       `json:"chainId"`
+	ChainName     string       `json:"chainName"`
+	RPC           string       `json:"rpc"`
+	REST          string       `json:"rest"`
+	BIP44         BIP44        `json:"bip44"`
+	Bech32Config  Bech32Config `json:"bech32Config"`
+	Currencies    []Currency   `json:"currencies"`
+	FeeCurrencies []Currency   `json:"feeCurrencies"`
+	StakeCurrency *Currency    `json:"stakeCurrency,omitempty"`
+}
+
+type BIP44 struct {
+	CoinType int64 `json:"coinType"`
+}
+
+type Bech32Config struct {
+	Bech32PrefixAccAddr  string `json:"bech32PrefixAccAddr"`
+	Bech32PrefixAccPub   string `json:"bech32PrefixAccPub"`
+	Bech32PrefixValAddr  string `json:"bech32PrefixValAddr"`
+	Bech32PrefixValPub   string `json:"bech32PrefixValPub"`
+	Bech32PrefixConsAddr string `json:"bech32PrefixConsAddr"`
+	Bech32PrefixConsPub  string `json:"bech32PrefixConsPub"`
+}
+
+type Currency struct {
+	CoinDenom        string  `json:"coinDenom"`
+	CoinMinimalDenom string  `json:"coinMinimalDenom"`
+	CoinDecimals     int64   `json:"coinDecimals"`
+	CoinGeckoID      *string `json:"coinGeckoId,omitempty"`
+	CoinImageURL     *string `json:"coinImageUrl,omitempty"`
+}
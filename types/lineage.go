@@ -0,0 +1,30 @@
+package types
+
+// AssetLineage is the path an asset took from the asset it originates from,
+// as recorded by the traces of the registry's asset lists
+type AssetLineage struct {
+	Chain string       `json:"chain"`
+	Asset AssetElement `json:"asset"`
+	// Steps lead from the asset back to its origin, each naming the asset the
+	// previous one came from
+	Steps []LineageStep `json:"steps"`
+	// Complete is set if the last step, or the asset itself if there are no
+	// steps, is registered without traces of its own
+	Complete bool `json:"complete"`
+}
+
+// LineageStep is a hop of an asset, such as an IBC transfer, a cw20 wrap or a
+// bridge, from the asset it came from
+type LineageStep struct {
+	// Type of the hop as named by the registry's traces, e.g. ibc, ibc-cw20,
+	// bridge, wrapped or liquid-stake
+	Type string `json:"type"`
+	// Chain and Denom identify the asset the hop came from
+	Chain string `json:"chain"`
+	Denom string `json:"denom"`
+	// Channel is the channel of the counterparty chain for IBC hops
+	Channel  string `json:"channel,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	// Asset is the asset the hop came from, if the registry lists it
+	Asset *AssetElement `json:"asset,omitempty"`
+}
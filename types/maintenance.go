@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// Maintenance describes the maintenance mode of the server. While enabled,
+// pulls are paused and queries are either refused or, if ReadOnly, served
+// from the current registry with a warning.
+type Maintenance struct {
+	Enabled  bool `json:"enabled"`
+	ReadOnly bool `json:"read_only"`
+	// Message explains the maintenance to clients
+	Message string `json:"message,omitempty"`
+	// RetryAfter is the number of seconds clients are asked to wait before
+	// retrying refused queries
+	RetryAfter int       `json:"retry_after,omitempty"`
+	Since      time.Time `json:"since,omitempty"`
+}
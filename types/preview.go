@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// Preview is the registry at another git ref, such as the head of a pull
+// request, served under /preview/{name}
+type Preview struct {
+	Name string `json:"name"`
+	// Ref is the previewed git ref, e.g. refs/pull/1234/head
+	Ref string `json:"ref"`
+	// State is loading while the ref is pulled, then ready, or failed with
+	// the Error of the last pull. A preview keeps serving the last ref it
+	// loaded while it is pulled again.
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+	// Commit is the commit of the ref when it was last loaded
+	Commit   string    `json:"commit,omitempty"`
+	LoadedAt time.Time `json:"loaded_at"`
+	Chains   int       `json:"chains"`
+}
@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// AssetPrice is the price of an asset as resolved from its coingecko id
+type AssetPrice struct {
+	USD     float64   `json:"usd"`
+	Updated time.Time `json:"updated"`
+}
+
+// PricedAsset is an asset with its current price, returned when
+// ?include=price is passed
+type PricedAsset struct {
+	AssetElement
+	Price *AssetPrice `json:"price,omitempty"`
+}
+
+// PricedAssetList is an asset list with the current price of each asset
+type PricedAssetList struct {
+	Assets  []PricedAsset `json:"assets"`
+	ChainID string        `json:"chain_id"`
+}
@@ -0,0 +1,21 @@
+package types
+
+// RlyChain is the chain configuration file accepted by `rly chains add --file`
+type RlyChain struct {
+	Type  string        `json:"type"`
+	Value RlyChainValue `json:"value"`
+}
+
+type RlyChainValue struct {
+	Key            string  `json:"key"`
+	ChainID        string  `json:"chain-id"`
+	RPCAddr        string  `json:"rpc-addr"`
+	AccountPrefix  string  `json:"account-prefix"`
+	KeyringBackend string  `json:"keyring-backend"`
+	GasAdjustment  float64 `json:"gas-adjustment"`
+	GasPrices      string  `json:"gas-prices"`
+	Debug          bool    `json:"debug"`
+	Timeout        string  `json:"timeout"`
+	OutputFormat   string  `json:"output-format"`
+	SignMode       string  `json:"sign-mode"`
+}
@@ -0,0 +1,42 @@
+package types
+
+import "time"
+
+// Stats summarises the size of the registry
+type Stats struct {
+	Chains      int       `json:"chains"`
+	Assets      int       `json:"assets"`
+	LastUpdated time.Time `json:"last_updated"`
+	// Commit is the latest commit of the registry, if the source has commits
+	Commit string `json:"commit,omitempty"`
+}
+
+// RegistryStats aggregates the chains and assets of the registry
+type RegistryStats struct {
+	// ChainsByNetworkType counts the chains of each network type. Chains
+	// without a network type are counted as "unknown".
+	ChainsByNetworkType map[string]int `json:"chains_by_network_type"`
+	// ChainsByStatus counts the chains of each status. Chains without a
+	// status are counted as "unknown".
+	ChainsByStatus map[string]int `json:"chains_by_status"`
+	// AssetsByChain counts the assets of each chain with an asset list
+	AssetsByChain map[string]int `json:"assets_by_chain"`
+	// FilesBySchemaVersion counts the chain.json and assetlist.json files
+	// pulled in each version of the registry schema
+	FilesBySchemaVersion map[string]int `json:"files_by_schema_version"`
+	// UnknownFields lists the fields of each file, keyed as chain/file, that
+	// skychart doesn't serve
+	UnknownFields map[string][]string `json:"unknown_fields"`
+}
+
+// ProviderStats aggregates the endpoints a provider runs across the registry
+type ProviderStats struct {
+	Provider string `json:"provider"`
+	// Chains counts the chains the provider runs any endpoint of
+	Chains int `json:"chains"`
+	// ChainsByEndpoint counts the chains the provider runs each kind of
+	// endpoint of: rpc, rest, grpc, peers or seeds
+	ChainsByEndpoint map[string]int `json:"chains_by_endpoint"`
+	// Endpoints counts the endpoints of each kind the provider runs
+	Endpoints map[string]int `json:"endpoints"`
+}
@@ -0,0 +1,65 @@
+package types
+
+import "time"
+
+// ServerStatus describes how up to date the registry served by skychart is
+type ServerStatus struct {
+	Registry string `json:"registry"`
+	// Branch is the branch served, or the commit SHA or tag if Pinned
+	Branch string `json:"branch"`
+	Pinned bool   `json:"pinned"`
+	// LastUpdated is when the registry was last confirmed to match github
+	LastUpdated time.Time `json:"last_updated"`
+	// Age is the number of seconds since LastUpdated
+	Age int64 `json:"age"`
+	// LastAttempt is when the server last tried to pull the registry
+	LastAttempt time.Time `json:"last_attempt"`
+	// LastError is the error of the last pull, if it failed
+	LastError string `json:"last_error,omitempty"`
+	// LastPullDuration is how long the last pull took in seconds
+	LastPullDuration float64 `json:"last_pull_duration"`
+	// ChainErrors are the errors fetching each chain during the last pull
+	// that fetched the registry. These chains continue to be served as they
+	// were before.
+	ChainErrors map[string]string `json:"chain_errors,omitempty"`
+	// RateLimit is the github api rate limit, if the registry is pulled from
+	// github
+	RateLimit *RateLimit `json:"rate_limit,omitempty"`
+	// Fetches are the requests made to github since the server started, if
+	// the registry is pulled from github
+	Fetches *FetchMetrics `json:"fetches,omitempty"`
+	// NextPull is when the registry will next be pulled. It is unset if no
+	// pull is scheduled, for example because the registry is pinned.
+	NextPull *time.Time `json:"next_pull,omitempty"`
+	// Stale is true once the registry is older than the server's max staleness
+	Stale bool `json:"stale"`
+	// AwaitingPull is true while a server that started serving before its
+	// initial pull waits for it to succeed
+	AwaitingPull bool `json:"awaiting_pull,omitempty"`
+	// Maintenance is set while the server is in maintenance mode
+	Maintenance *Maintenance `json:"maintenance,omitempty"`
+}
+
+// RateLimit is a rate limit reported by an upstream api
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// FetchMetrics describes the requests made to an upstream while pulling
+type FetchMetrics struct {
+	Requests int64 `json:"requests"`
+	// Bytes is the number of bytes downloaded
+	Bytes int64 `json:"bytes"`
+	// Files is the latency of fetching each file of the registry, with the
+	// other requests to the upstream under "api"
+	Files map[string]FetchLatency `json:"files"`
+}
+
+// FetchLatency is the mean and maximum latency of a fetch in seconds
+type FetchLatency struct {
+	Count int64   `json:"count"`
+	Mean  float64 `json:"mean"`
+	Max   float64 `json:"max"`
+}
@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+// AssetSupply is the total supply of an asset as reported by its chain's bank
+// module
+type AssetSupply struct {
+	Chain string `json:"chain"`
+	Denom string `json:"denom"`
+	// Amount is the total supply in the base denom
+	Amount string `json:"amount"`
+	// Endpoint is the REST endpoint the supply was queried from
+	Endpoint string    `json:"endpoint"`
+	Updated  time.Time `json:"updated"`
+}
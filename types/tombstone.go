@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+// Tombstone records a chain that was removed from the registry
+type Tombstone struct {
+	Chain   string `json:"chain"`
+	ChainID string `json:"chain_id,omitempty"`
+	// Removed is when the chain was first pulled without the chain
+	Removed time.Time `json:"removed"`
+	// Successor is the chain that took over the removed chain's chain id,
+	// if any
+	Successor string `json:"successor,omitempty"`
+}
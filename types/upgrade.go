@@ -0,0 +1,21 @@
+package types
+
+// Upgrade is a version of a chain's software laid out for Cosmovisor, which
+// runs the binary of each version from its own directory and switches to the
+// next at the upgrade height
+type Upgrade struct {
+	Name string `json:"name"`
+	// Height is the height of the upgrade. It is omitted for the version the
+	// chain was launched with.
+	Height  *float64 `json:"height,omitempty"`
+	Version string   `json:"version,omitempty"`
+	// Binaries are the download URLs of the binary keyed by platform, e.g.
+	// linux/amd64, optionally with a ?checksum= query
+	Binaries map[string]string `json:"binaries,omitempty"`
+	// Info is the upgrade info in the format Cosmovisor reads from an upgrade
+	// plan to download the binaries automatically
+	Info string `json:"info,omitempty"`
+	// Dir is the directory within Cosmovisor's home the binary is installed
+	// in, e.g. upgrades/v4
+	Dir string `json:"dir"`
+}
@@ -0,0 +1,24 @@
+package types
+
+import "time"
+
+// Usage counts the requests served since the server started
+type Usage struct {
+	Since    time.Time `json:"since"`
+	Requests int64     `json:"requests"`
+	// Routes are keyed by the path template of the route, e.g.
+	// /v1/chain/{chain}
+	Routes map[string]RouteUsage `json:"routes"`
+	// Consumers are keyed by a fingerprint of the API key, or anonymous for
+	// requests without a valid key. It is empty when authentication is
+	// disabled.
+	Consumers map[string]int64 `json:"consumers"`
+	// Chains counts the successful requests for each chain
+	Chains map[string]int64 `json:"chains"`
+}
+
+// RouteUsage counts the requests to a route by the status code of the response
+type RouteUsage struct {
+	Requests int64         `json:"requests"`
+	Statuses map[int]int64 `json:"statuses"`
+}